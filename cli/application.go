@@ -6,10 +6,12 @@ import (
 )
 
 // NewApplication returns a new console application. The given constraints are used to produce the
-// generate command, allowing binaries with custom validation constraints to be built easily.
+// generate command, allowing binaries with custom validation constraints to be built easily. The
+// config command is also added, to show the configuration that code would be generated from.
 func NewApplication(constraints map[string]valley.ConstraintGenerator) *console.Application {
 	application := console.NewApplication("valley", "SNAPSHOT")
 	application.SetRootCommand(RootCommand(constraints))
+	application.AddCommand(ConfigCommand())
 
 	return application
 }
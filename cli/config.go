@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/config"
+	"github.com/seeruk/valley/source"
+)
+
+// ConfigCommand returns the console command used when `valley config` is run. It reads a Go file,
+// builds configuration up from it in the same way as the root command, and writes that to stdout
+// as JSON, instead of generating code. This shows exactly what Valley understood from the file,
+// with expressions rendered back to source code, and the position each constraint was declared at.
+func ConfigCommand() *console.Command {
+	var srcPath string
+
+	flags := newConfigFlags()
+
+	configure := func(def *console.Definition) {
+		flags.define(def)
+
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&srcPath),
+			Spec:  "SOURCE",
+			Desc:  "The path to a file to show configuration for, or '-' for stdin.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		builder, _, err := flags.builder()
+		if err != nil {
+			return err
+		}
+
+		bs, err := builder.dump(srcPath)
+		if err != nil {
+			reportError(builder.reporter, srcPath, err)
+			output.SetExitCode(1)
+			return nil
+		}
+
+		output.Println(string(bs))
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "config",
+		Description: "Shows the configuration built from a Go file as JSON",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
+
+// configFlags holds the values of the options that affect how configuration is built, which are
+// shared by each command that builds it.
+type configFlags struct {
+	constraintsTag string
+	validatorTag   string
+	configPath     string
+	strict         bool
+	diagnostics    string
+}
+
+// newConfigFlags returns a new configFlags, with default values set.
+func newConfigFlags() *configFlags {
+	return &configFlags{
+		diagnostics: "text",
+	}
+}
+
+// define adds the options that configFlags holds the values of to the given definition.
+func (f *configFlags) define(def *console.Definition) {
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewStringValue(&f.constraintsTag),
+		Spec:  "--constraints-tag=NAME",
+		Desc:  "Also read constraints from rules in struct tags with the given NAME (e.g. 'valley')",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewStringValue(&f.validatorTag),
+		Spec:  "--validator-tag=NAME",
+		Desc:  "Also read constraints from go-playground/validator rules in struct tags with the given NAME (e.g. 'validate')",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewStringValue(&f.configPath),
+		Spec:  "--config=FILE",
+		Desc:  "Also read constraints for types from the given JSON FILE",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewBoolValue(&f.strict),
+		Spec:  "--strict",
+		Desc:  "Treat warnings as errors, failing instead of skipping anything that may not be valid",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewStringValue(&f.diagnostics),
+		Spec:  "--diagnostics=FORMAT",
+		Desc:  "Write warnings and errors to stderr in the given FORMAT, 'text' or 'json' (Default: 'text')",
+	})
+}
+
+// builder returns a configBuilder that builds configuration as these flags describe, and the
+// options needed to find source files that may have configuration built from them.
+func (f *configFlags) builder() (configBuilder, []source.FindOption, error) {
+	var findOpts []source.FindOption
+
+	reporter, err := newReporter(f.diagnostics, os.Stderr)
+	if err != nil {
+		return configBuilder{}, nil, err
+	}
+
+	builder := configBuilder{
+		// The same reader is used for every file, so that module information is only looked up once.
+		reader:         source.NewReader(),
+		reporter:       reporter,
+		constraintsTag: f.constraintsTag,
+		validatorTag:   f.validatorTag,
+		strict:         f.strict,
+	}
+
+	if f.configPath != "" {
+		file, err := config.ReadFile(f.configPath)
+		if err != nil {
+			return builder, nil, err
+		}
+
+		builder.configFile = &file
+		findOpts = append(findOpts, source.WithTypes(file.TypeNames()...))
+	}
+
+	for _, tagName := range []string{f.constraintsTag, f.validatorTag} {
+		if tagName != "" {
+			findOpts = append(findOpts, source.WithTags(tagName))
+		}
+	}
+
+	return builder, findOpts, nil
+}
+
+// configBuilder holds everything needed to build configuration for each source file.
+type configBuilder struct {
+	reader         *source.Reader
+	reporter       valley.Reporter
+	constraintsTag string
+	validatorTag   string
+	configFile     *config.File
+	strict         bool
+}
+
+// read reads the Go file at the given source path. If the source path is "-", the file is read from
+// stdin instead, and is treated as a standalone file, as it has no package on disk.
+func (b configBuilder) read(srcPath string) (valley.Source, error) {
+	if srcPath != stdio {
+		return b.reader.Read(token.NewFileSet(), srcPath)
+	}
+
+	bs, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return valley.Source{}, fmt.Errorf("failed to read stdin: %v", err)
+	}
+
+	return b.reader.ReadStandalone(token.NewFileSet(), stdinFileName, bs)
+}
+
+// readPackage reads the Go files at the given source paths, which are all in the same directory,
+// reading their package only once. The source path may also be stdin, on it's own.
+func (b configBuilder) readPackage(srcPaths []string) ([]valley.Source, error) {
+	if len(srcPaths) == 1 && srcPaths[0] == stdio {
+		src, err := b.read(stdio)
+		return []valley.Source{src}, err
+	}
+
+	return b.reader.ReadPackage(token.NewFileSet(), srcPaths)
+}
+
+// build builds configuration for the given Source, from it's constraints methods, and any other
+// configuration sources that are enabled, merging them together.
+func (b configBuilder) build(src valley.Source) (valley.Config, error) {
+	configOpts := []config.Option{
+		config.WithReporter(b.reporter),
+		config.WithStrict(b.strict),
+	}
+
+	cfg, err := config.BuildFromSource(src, configOpts...)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to generate config from source: %w", err)
+	}
+
+	if b.constraintsTag != "" {
+		tagsCfg, err := config.BuildFromTags(src, b.constraintsTag, configOpts...)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to generate config from struct tags: %w", err)
+		}
+
+		cfg, err = config.Merge(cfg, tagsCfg)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to merge config from struct tags: %w", err)
+		}
+	}
+
+	if b.validatorTag != "" {
+		validatorCfg, err := config.BuildFromValidatorTags(src, b.validatorTag, configOpts...)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to generate config from validator struct tags: %w", err)
+		}
+
+		cfg, err = config.Merge(cfg, validatorCfg)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to merge config from validator struct tags: %w", err)
+		}
+	}
+
+	if b.configFile != nil {
+		fileCfg, err := config.BuildFromFile(src, *b.configFile)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to generate config from config file: %w", err)
+		}
+
+		cfg, err = config.Merge(cfg, fileCfg)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to merge config from config file: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// dump reads the Go file at the given source path (or stdin), and returns the configuration built
+// for it as indented JSON.
+func (b configBuilder) dump(srcPath string) ([]byte, error) {
+	src, err := b.read(srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source: %w", err)
+	}
+
+	cfg, err := b.build(src)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := config.ToFile(src, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert config: %w", err)
+	}
+
+	return json.MarshalIndent(file, "", "  ")
+}
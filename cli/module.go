@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/seeruk/valley"
+)
+
+// moduleReporter is a Reporter that makes the file path of each Diagnostic relative to the root of
+// the module the file is in, before passing it on to another Reporter, so that every Diagnostic
+// refers to the same file in the same way. The module roots are only looked up once, the first time
+// a Diagnostic with a file path is reported.
+type moduleReporter struct {
+	reporter valley.Reporter
+	dirs     []string
+	dirsRead bool
+}
+
+// newModuleReporter returns a new moduleReporter, reporting to the given Reporter.
+func newModuleReporter(reporter valley.Reporter) *moduleReporter {
+	return &moduleReporter{reporter: reporter}
+}
+
+// Report makes the given diagnostic's file path relative to it's module root, and reports it.
+func (r *moduleReporter) Report(diagnostic valley.Diagnostic) {
+	if diagnostic.File != "" {
+		if !r.dirsRead {
+			r.dirs = readModuleDirs()
+			r.dirsRead = true
+		}
+
+		diagnostic.File = modulePath(r.dirs, diagnostic.File)
+	}
+
+	r.reporter.Report(diagnostic)
+}
+
+// readModuleDirs returns the root directories of the main modules, of which there may be many if a
+// go.work file is in use. If they can't be found, nil is returned.
+func readModuleDirs() []string {
+	bs, err := exec.Command("go", "list", "-m", "-json").Output()
+	if err != nil {
+		return nil
+	}
+
+	return decodeModuleDirs(bs)
+}
+
+// decodeModuleDirs returns the directories of the modules in the given output of `go list -m -json`,
+// which is a stream of JSON objects, one for each module.
+func decodeModuleDirs(bs []byte) []string {
+	var dirs []string
+
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+
+	for {
+		var module valley.Module
+
+		err := decoder.Decode(&module)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return dirs
+		}
+
+		if module.Dir != "" {
+			dirs = append(dirs, module.Dir)
+		}
+	}
+
+	return dirs
+}
+
+// modulePath returns the given file path relative to the root of the module it's in, choosing the
+// innermost of the given module roots. If it's not in any of them, the absolute path is returned
+// instead (or the given path, if that can't be found either).
+func modulePath(dirs []string, path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	var moduleDir string
+	for _, dir := range dirs {
+		if strings.HasPrefix(absPath, dir+string(os.PathSeparator)) && len(dir) > len(moduleDir) {
+			moduleDir = dir
+		}
+	}
+
+	if moduleDir == "" {
+		return absPath
+	}
+
+	return strings.TrimPrefix(absPath, moduleDir+string(os.PathSeparator))
+}
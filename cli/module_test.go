@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeModuleDirs(t *testing.T) {
+	t.Run("should decode the directory of each module", func(t *testing.T) {
+		bs := []byte(`{"Path": "example.com/a", "Dir": "/work/a"}
+{"Path": "example.com/b", "Dir": "/work/b"}
+`)
+
+		assert.Equal(t, []string{"/work/a", "/work/b"}, decodeModuleDirs(bs))
+	})
+
+	t.Run("should return nil if there are no modules", func(t *testing.T) {
+		assert.Nil(t, decodeModuleDirs(nil))
+	})
+}
+
+func TestModulePath(t *testing.T) {
+	root := filepath.FromSlash("/work")
+	nested := filepath.Join(root, "nested")
+
+	t.Run("should return paths relative to the innermost module root", func(t *testing.T) {
+		dirs := []string{root, nested}
+
+		assert.Equal(t, filepath.FromSlash("a/a.go"), modulePath(dirs, filepath.Join(root, "a", "a.go")))
+		assert.Equal(t, "b.go", modulePath(dirs, filepath.Join(nested, "b.go")))
+	})
+
+	t.Run("should return absolute paths for files outside of every module", func(t *testing.T) {
+		path := filepath.Join(filepath.FromSlash("/elsewhere"), "c.go")
+		assert.Equal(t, path, modulePath([]string{root}, path))
+	})
+}
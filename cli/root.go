@@ -1,32 +1,49 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
-	"go/parser"
-	"go/token"
+	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/seeruk/go-console"
 	"github.com/seeruk/go-console/parameters"
 	"github.com/seeruk/valley"
-	"github.com/seeruk/valley/config"
 	"github.com/seeruk/valley/source"
 	"github.com/seeruk/valley/validation"
 )
 
+// stdio is the path used to refer to stdin (as SOURCE), or stdout (as DEST).
+const stdio = "-"
+
+// stdinFileName is the file name given to source code read from stdin. It's used in messages, and in
+// the names of generated variables, so it must be usable in a Go identifier once it's extension is
+// removed.
+const stdinFileName = "stdin.go"
+
 // RootCommand returns the root console command used when valley is run. This contains the logic to
-// orchestrate reading a Go file, building configuration up from that Go file, generating a set of
-// validation source code, formatting that source code, and then writing that to a destination file.
+// orchestrate reading a Go file (and the rest of it's package), building configuration up from that
+// Go file, generating a set of validation source code, formatting that source code, and then writing
+// that to a destination file. SOURCE may also be a directory, or a pattern like "./...", in which
+// case this is done for every file found that declares constraints. If SOURCE is "-", a single file
+// is read from stdin, and by default the generated code is written to stdout.
 func RootCommand(constraints map[string]valley.ConstraintGenerator) *console.Command {
 	var srcPath string
 	var destPath string
+	var check bool
+	var validEmbedded bool
+	var autoValid bool
+	var validateErr bool
 
 	tagName := "valley"
+	flags := newConfigFlags()
 
 	configure := func(def *console.Definition) {
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewStringValue(&destPath),
 			Spec:  "-o,--output=DEST",
-			Desc:  "Write output to DEST instead of the default '_validate.go'",
+			Desc:  "Write output to DEST instead of the default '_validate.go', or '-' for stdout (only if SOURCE is one file)",
 		})
 
 		def.AddOption(console.OptionDefinition{
@@ -35,42 +52,122 @@ func RootCommand(constraints map[string]valley.ConstraintGenerator) *console.Com
 			Desc:  "Use the given tag name to override field names in generated output (Default: 'valley')",
 		})
 
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&check),
+			Spec:  "--check",
+			Desc:  "Check generated code is up to date instead of writing it, showing a diff and failing if not",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&validEmbedded),
+			Spec:  "--valid-embedded",
+			Desc:  "Also validate embedded structs that have a validation method of the same name, as if they were given the Valid constraint",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&autoValid),
+			Spec:  "--auto-valid",
+			Desc:  "Also validate fields, elements, and map values whose types have a validation method of the same name, as if they were given the Valid constraint",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&validateErr),
+			Spec:  "--validate-err",
+			Desc:  "Also generate a method alongside each validation method (e.g. 'ValidateErr') that returns violations as an error",
+		})
+
+		flags.define(def)
+
 		def.AddArgument(console.ArgumentDefinition{
 			Value: parameters.NewStringValue(&srcPath),
 			Spec:  "SOURCE",
-			Desc:  "The path to a file to generate validation code for.",
+			Desc:  "The path to a file, or directory (e.g. './...' to include subdirectories) to generate validation code for, or '-' for stdin.",
 		})
 	}
 
-	execute := func(int *console.Input, output *console.Output) error {
-		fileSet := token.NewFileSet()
-
-		file, err := parser.ParseFile(fileSet, srcPath, nil, 0)
+	execute := func(input *console.Input, output *console.Output) error {
+		builder, findOpts, err := flags.builder()
 		if err != nil {
-			return fmt.Errorf("failed to parse source: %v", err)
+			return err
 		}
 
-		src := source.Read(fileSet, file, srcPath)
+		srcPaths := []string{srcPath}
 
-		cfg, err := config.BuildFromSource(src)
-		if err != nil {
-			return fmt.Errorf("failed to generate config from source: %v", err)
+		if srcPath == stdio && destPath == "" {
+			destPath = stdio
+		} else if srcPath != stdio {
+			srcPaths, err = source.Find(srcPath, findOpts...)
+			if err != nil {
+				return err
+			}
 		}
 
-		generator := validation.NewGenerator(constraints)
+		if check && destPath == stdio {
+			return errors.New("cannot use --check when writing to stdout")
+		}
 
-		bs, err := generator.Generate(cfg, src, tagName)
-		if err != nil {
-			return fmt.Errorf("failed to generate validation code: %v", err)
+		if len(srcPaths) == 0 {
+			fmt.Fprintf(os.Stderr, "valley: no files declaring constraints found in %q\n", srcPath)
+			return nil
 		}
 
-		if destPath == "" {
-			destPath = validation.FindDestination(srcPath)
+		if destPath != "" && len(srcPaths) > 1 {
+			return fmt.Errorf("cannot use --output when generating code for multiple files (found %d)", len(srcPaths))
 		}
 
-		err = validation.FormatAndWrite(bs, destPath)
-		if err != nil {
-			return fmt.Errorf("failed to write generated code to destination file: %v", err)
+		r := runner{
+			configBuilder: builder,
+			constraints:   constraints,
+			output:        output,
+			tagName:       tagName,
+			check:         check,
+			validEmbedded: validEmbedded,
+			autoValid:     autoValid,
+			validateErr:   validateErr,
+		}
+
+		var failed, stale int
+
+		for _, pkgSrcPaths := range groupByDir(srcPaths) {
+			srcs, err := r.readPackage(pkgSrcPaths)
+			if err != nil {
+				reportError(r.reporter, pkgSrcPaths[0], fmt.Errorf("failed to read source: %w", err))
+				failed += len(pkgSrcPaths)
+				continue
+			}
+
+			for i, src := range srcs {
+				srcPath := pkgSrcPaths[i]
+
+				fileDestPath := destPath
+				if fileDestPath == "" {
+					fileDestPath = validation.FindDestination(srcPath)
+				}
+
+				isStale, err := r.run(src, fileDestPath)
+				if err != nil {
+					reportError(r.reporter, srcPath, err)
+					failed++
+					continue
+				}
+
+				if isStale {
+					stale++
+				}
+			}
+		}
+
+		if stale > 0 {
+			fmt.Fprintf(os.Stderr, "valley: generated code is out of date for %d file(s), run valley to regenerate it\n", stale)
+			output.SetExitCode(1)
+		}
+
+		if failed > 0 {
+			if len(srcPaths) > 1 {
+				fmt.Fprintf(os.Stderr, "valley: failed to generate code for %d of %d file(s)\n", failed, len(srcPaths))
+			}
+
+			output.SetExitCode(1)
 		}
 
 		return nil
@@ -82,3 +179,127 @@ func RootCommand(constraints map[string]valley.ConstraintGenerator) *console.Com
 		Execute:     execute,
 	}
 }
+
+// runner holds everything needed to generate validation code for each source file.
+type runner struct {
+	configBuilder
+
+	constraints   map[string]valley.ConstraintGenerator
+	output        *console.Output
+	tagName       string
+	check         bool
+	validEmbedded bool
+	autoValid     bool
+	validateErr   bool
+}
+
+// run generates validation code for the given Source, and writes it to the given destination path
+// (or stdout). If checking, the code is instead compared to the existing
+// code at the destination path, and a diff is written to the output if they differ, in which case
+// true is returned.
+func (r runner) run(src valley.Source, destPath string) (bool, error) {
+	bs, err := r.generate(src)
+	if err != nil {
+		return false, err
+	}
+
+	if r.check {
+		diff, err := validation.Check(bs, destPath)
+		if err != nil {
+			return false, err
+		}
+
+		r.output.Print(diff)
+
+		return diff != "", nil
+	}
+
+	if destPath == stdio {
+		err = validation.FormatAndWriteTo(bs, r.output.Writer)
+		if err != nil {
+			return false, fmt.Errorf("failed to write generated code to stdout: %w", err)
+		}
+
+		return false, nil
+	}
+
+	err = validation.FormatAndWrite(bs, destPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to write generated code to destination file: %w", err)
+	}
+
+	return false, nil
+}
+
+// generate returns the (unformatted) validation code generated for the given Source.
+func (r runner) generate(src valley.Source) ([]byte, error) {
+	cfg, err := r.build(src)
+	if err != nil {
+		return nil, err
+	}
+
+	generator := validation.NewGenerator(r.constraints,
+		validation.WithReporter(r.reporter),
+		validation.WithStrict(r.strict),
+		validation.WithValidEmbedded(r.validEmbedded),
+		validation.WithAutoValid(r.autoValid),
+		validation.WithValidateErr(r.validateErr),
+	)
+
+	bs, err := generator.Generate(cfg, src, r.tagName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate validation code: %w", err)
+	}
+
+	return bs, nil
+}
+
+// groupByDir groups the given source paths by the directory they're in (and so, usually, by their
+// package), keeping them in order, so that each package only has to be read once.
+func groupByDir(srcPaths []string) [][]string {
+	var groups [][]string
+
+	indexes := make(map[string]int)
+	for _, srcPath := range srcPaths {
+		dir := filepath.Dir(srcPath)
+
+		i, ok := indexes[dir]
+		if !ok {
+			i = len(groups)
+			indexes[dir] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], srcPath)
+	}
+
+	return groups
+}
+
+// newReporter returns a Reporter that writes diagnostics to the given writer in the given format,
+// with file paths relative to the root of their module.
+func newReporter(format string, w io.Writer) (valley.Reporter, error) {
+	switch format {
+	case "text":
+		return newModuleReporter(valley.NewTextReporter(w)), nil
+	case "json":
+		return newModuleReporter(valley.NewJSONReporter(w)), nil
+	default:
+		return nil, fmt.Errorf("unknown diagnostics format: %q", format)
+	}
+}
+
+// reportError reports the given error, which occurred whilst generating code for the given source
+// path. Errors that aren't already Diagnostics are reported against the source file as a whole.
+func reportError(reporter valley.Reporter, srcPath string, err error) {
+	var diagnostic valley.Diagnostic
+	if !errors.As(err, &diagnostic) {
+		diagnostic = valley.Diagnostic{
+			File:     srcPath,
+			Severity: valley.SeverityError,
+			Message:  err.Error(),
+		}
+	}
+
+	reporter.Report(diagnostic)
+}
@@ -0,0 +1,18 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByDir(t *testing.T) {
+	t.Run("should group source paths by directory, in order", func(t *testing.T) {
+		groups := groupByDir([]string{"a/x.go", "b/y.go", "a/z.go", "a/b/w.go"})
+		assert.Equal(t, [][]string{{"a/x.go", "a/z.go"}, {"b/y.go"}, {"a/b/w.go"}}, groups)
+	})
+
+	t.Run("should return nil if there are no source paths", func(t *testing.T) {
+		assert.Nil(t, groupByDir(nil))
+	})
+}
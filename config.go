@@ -5,13 +5,22 @@ import (
 	"go/token"
 )
 
-// Config represents the configuration for generating an entire set of validation code.
+// Config represents the configuration for generating an entire set of validation code. Each type
+// may have multiple TypeConfigs, one for each validation method that should be generated for it.
 type Config struct {
-	Types map[string]TypeConfig `json:"types"`
+	Types map[string][]TypeConfig `json:"types"`
 }
 
-// TypeConfig represents the configuration needed to generate validation code for a specific type.
+// TypeConfig represents the configuration needed to generate a validation method for a specific
+// type. Name is the name of the method to generate (e.g. "Validate", or "ValidateCreate"), and
+// Receiver is the receiver name used by any expressions in the configuration. If Function is true,
+// a function accepting the value (named after Receiver) is generated instead of a method, and if
+// Pointer is also true, that function accepts a pointer to the value.
 type TypeConfig struct {
+	Name        string                 `json:"name"`
+	Receiver    string                 `json:"receiver"`
+	Function    bool                   `json:"function"`
+	Pointer     bool                   `json:"pointer"`
 	Constraints []ConstraintConfig     `json:"constraints"`
 	Fields      map[string]FieldConfig `json:"fields"`
 }
@@ -25,10 +34,13 @@ type FieldConfig struct {
 }
 
 // ConstraintConfig represents the configuration passed to a ConstraintGenerator to generate some
-// code. It's used throughout the configuration structure.
+// code. It's used throughout the configuration structure. Message and Code, if set, override the
+// message and code of the violations the constraint produces.
 type ConstraintConfig struct {
 	Predicate ast.Expr
 	Name      string     `json:"name"`
 	Opts      []ast.Expr `json:"opts"`
+	Message   string     `json:"message,omitempty"`
+	Code      string     `json:"code,omitempty"`
 	Pos       token.Pos
 }
@@ -1,55 +1,178 @@
 package config
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
-	"os"
-	"os/exec"
-	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/seeruk/valley"
 )
 
-// importPath is the import path that is used to import Valley types.
-const importPath = "github.com/seeruk/valley"
+// BuildFromSource builds Config for all types in a given Source by picking out each type that has
+// constraints methods defined (in the source file, though the type itself may be declared in any
+// file in the same package), and using the body of those methods to produce the configuration. Each
+// constraints method produces configuration for a separate validation method.
+//
+// Constraints functions (e.g. `func UserConstraints(u User, t valley.Type)`) are also picked out,
+// which allows types from other packages to be validated. Each of these produces configuration for
+// a separate validation function instead.
+//
+// Warnings (e.g. about statements that are skipped) are reported using the Reporter given in the
+// options, if there is one. Errors are returned as valley.Diagnostics where possible.
+func BuildFromSource(src valley.Source, opts ...Option) (valley.Config, error) {
+	options := options{
+		reporter: valley.DiscardReporter,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
 
-// BuildFromSource builds Config for all types in a given Source by picking out each type that has a
-// constraints method defined (in the same file), and using the body of those methods to produce the
-// configuration.
-func BuildFromSource(src valley.Source) (valley.Config, error) {
 	config := valley.Config{
-		Types: make(map[string]valley.TypeConfig),
+		Types: make(map[string][]valley.TypeConfig),
 	}
 
 	constraintsMethods := collectConstraintsMethods(src)
+	constraintsFunctions := collectConstraintsFunctions(src)
 
-	for typeName, method := range constraintsMethods {
-		typeConfig, err := buildTypeConfig(src, method)
-		if err != nil {
-			return config, err
+	err := checkValidateMethodNames(src, constraintsMethods, constraintsFunctions)
+	if err != nil {
+		return config, err
+	}
+
+	for typeName, methods := range constraintsMethods {
+		for _, method := range methods {
+			typeConfig, err := buildTypeConfig(src, options, method)
+			if err != nil {
+				return config, err
+			}
+
+			config.Types[typeName] = append(config.Types[typeName], typeConfig)
 		}
+	}
 
-		config.Types[typeName] = typeConfig
+	for typeName, functions := range constraintsFunctions {
+		for _, function := range functions {
+			typeConfig, err := buildTypeConfig(src, options, function)
+			if err != nil {
+				return config, err
+			}
+
+			typeConfig.Function = true
+			typeConfig.Pointer = hasPointerParam(function)
+
+			config.Types[typeName] = append(config.Types[typeName], typeConfig)
+		}
 	}
 
 	return config, nil
 }
 
+// Option is a function that configures how Config is built.
+type Option func(o *options)
+
+// options holds the settings that affect how Config is built.
+type options struct {
+	reporter valley.Reporter
+	strict   bool
+}
+
+// WithReporter returns an Option that sets the Reporter that warnings found whilst building Config
+// are reported to. By default, warnings are ignored.
+func WithReporter(reporter valley.Reporter) Option {
+	return func(o *options) {
+		o.reporter = reporter
+	}
+}
+
+// WithStrict returns an Option that, if strict is true, makes warnings found whilst building Config
+// be returned as errors instead, so that nothing is silently skipped.
+func WithStrict(strict bool) Option {
+	return func(o *options) {
+		o.strict = strict
+	}
+}
+
+// ValidateMethodName returns the name of the validation method (or function) that is generated for
+// a constraints method (or function) with the given name. The "Constraints" suffix is swapped for a
+// "Validate" prefix, so the conventional `Constraints` method produces `Validate`,
+// `CreateConstraints` produces `ValidateCreate`, and a method named `Rules` would produce
+// `ValidateRules`.
+func ValidateMethodName(constraintsMethodName string) string {
+	return "Validate" + strings.TrimSuffix(constraintsMethodName, "Constraints")
+}
+
+// checkValidateMethodNames returns an error if two constraints methods on the same type (e.g. `X`,
+// and `XConstraints`), or two constraints functions, would generate validation methods (or
+// functions) with the same name. The error is reported at the later of the two.
+func checkValidateMethodNames(src valley.Source, methods, functions map[string][]valley.Method) error {
+	for typeName, typeMethods := range methods {
+		seen := make(map[string]string)
+
+		for _, method := range typeMethods {
+			err := checkValidateMethodName(src, seen, method, typeName+"'s constraints method")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var allFunctions []valley.Method
+	for _, typeFunctions := range functions {
+		allFunctions = append(allFunctions, typeFunctions...)
+	}
+
+	// Functions are all generated in the same package, whichever type they're for.
+	sort.Slice(allFunctions, func(i, j int) bool {
+		return allFunctions[i].Pos < allFunctions[j].Pos
+	})
+
+	seen := make(map[string]string)
+
+	for _, function := range allFunctions {
+		err := checkValidateMethodName(src, seen, function, "constraints function")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkValidateMethodName returns an error if the name generated for the given constraints method
+// has already been seen, otherwise it's added to the names that have been seen.
+func checkValidateMethodName(src valley.Source, seen map[string]string, method valley.Method, kind string) error {
+	name := ValidateMethodName(method.Name)
+
+	if previous, ok := seen[name]; ok {
+		return errorOn(src, method.Pos, "%s %q would generate %s, which is already generated for %q", kind, method.Name, name, previous)
+	}
+
+	seen[name] = method.Name
+
+	return nil
+}
+
 // buildTypeConfig builds TypeConfig based on the body of a constraints method in the given Source.
 // It does this by reading the Go AST for the file, and picking out calls that match the expected
 // usage for Valley.
-func buildTypeConfig(src valley.Source, method valley.Method) (valley.TypeConfig, error) {
+func buildTypeConfig(src valley.Source, options options, method valley.Method) (valley.TypeConfig, error) {
 	config := valley.TypeConfig{
-		Fields: make(map[string]valley.FieldConfig),
+		Name:     ValidateMethodName(method.Name),
+		Receiver: method.Receiver,
+		Fields:   make(map[string]valley.FieldConfig),
 	}
 
 	for _, stmt := range method.Body.List {
-		chain, ok := buildCallChain(src, method, stmt)
-		if !ok {
+		chain, err := buildCallChain(src, options, method, stmt)
+		if err != nil {
+			return config, err
+		}
+
+		if chain == nil {
 			continue
 		}
 
@@ -104,12 +227,12 @@ func buildTypeConfig(src valley.Source, method valley.Method) (valley.TypeConfig
 	return config, nil
 }
 
-// buildCallChain ...
-func buildCallChain(src valley.Source, method valley.Method, stmt ast.Stmt) (*callExprNode, bool) {
+// buildCallChain ... If the given statement can't be used, it's skipped with a warning, and a nil
+// chain is returned (unless warnings are treated as errors).
+func buildCallChain(src valley.Source, options options, method valley.Method, stmt ast.Stmt) (*callExprNode, error) {
 	exprStmt, ok := stmt.(*ast.ExprStmt)
 	if !ok {
-		warnOn(src, stmt.Pos(), "skipping line that is not a statement")
-		return nil, false
+		return nil, warnOn(src, options, stmt.Pos(), "skipping line that is not a statement")
 	}
 
 	// NOTE: Currently assumed to always work, not sure if this can ever not be true at this point.
@@ -121,26 +244,30 @@ func buildCallChain(src valley.Source, method valley.Method, stmt ast.Stmt) (*ca
 	// being called to determine how to behave from that point on.
 	chain, err := buildCallExpr(src, callExpr)
 	if err != nil {
-		warnOn(src, stmt.Pos(), "skipping line with unexpected structure: %v", err)
-		return nil, false
+		message := err.Error()
+		if diagnostic, ok := err.(valley.Diagnostic); ok {
+			// The position is the same as the statement's, so there's no need to repeat it.
+			message = diagnostic.Message
+		}
+
+		return nil, warnOn(src, options, stmt.Pos(), "skipping line with unexpected structure: %s", message)
 	}
 
 	chain = chain.Reverse()
 
-	// At this point we should know there is only one parameter, and it should be the
-	// valley.Type argument.
-	param := method.Params.List[0]
+	// At this point we should know the last parameter is the valley.Type argument. It's the only
+	// parameter of constraints methods, and follows the value in constraints functions.
+	param := method.Params.List[len(method.Params.List)-1]
 	paramName := param.Names[0].Name
 
 	if chain.Ident == nil || chain.Ident.Name != paramName {
 		// The call should be happening on the valley.Type. It doesn't have to be called `t`, so
 		// we get the parameter name and compare it to the root identifier of the call chain
 		// (i.e. the identifier all of the calls in the statement are coming off of).
-		warnOn(src, stmt.Pos(), "skipping call that isn't on valley.Type")
-		return nil, false
+		return nil, warnOn(src, options, stmt.Pos(), "skipping call that isn't on valley.Type")
 	}
 
-	return chain, true
+	return chain, nil
 }
 
 // buildConstraintsCall ...
@@ -180,8 +307,23 @@ func buildFieldsCall(src valley.Source, method valley.Method, predicate ast.Expr
 		return "", config, errorOn(src, typeMethod.Call.Pos(), "value passed to Field should be a selector")
 	}
 
-	fieldArgOn, ok := fieldArg.X.(*ast.Ident)
-	if !ok || fieldArgOn.Name != method.Receiver {
+	// The selector may be a chain through nested struct fields (e.g. `r.Address.Postcode`), so we
+	// walk back through it to the identifier it starts from, collecting each field name.
+	fieldNames := []string{fieldArg.Sel.Name}
+	fieldArgOn := fieldArg.X
+
+	for {
+		selector, ok := fieldArgOn.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		fieldNames = append([]string{selector.Sel.Name}, fieldNames...)
+		fieldArgOn = selector.X
+	}
+
+	fieldArgIdent, ok := fieldArgOn.(*ast.Ident)
+	if !ok || fieldArgIdent.Name != method.Receiver {
 		// The argument passed to Field must be on an ident (i.e. the type). Additionally,
 		// the argument passed to Field must be on the receiver for the constraints method.
 		return "", config, errorOn(src, fieldArg.Pos(), "value passed to Field should be a field on the receiver's type")
@@ -192,7 +334,7 @@ func buildFieldsCall(src valley.Source, method valley.Method, predicate ast.Expr
 		return "", config, err
 	}
 
-	return fieldArg.Sel.Name, fieldConfig, nil
+	return strings.Join(fieldNames, "."), fieldConfig, nil
 }
 
 // buildFieldConfig ...
@@ -304,6 +446,42 @@ func buildConstraintConfig(src valley.Source, predicate, expr ast.Expr) (valley.
 	config.Opts = constraintCall.Args
 	config.Pos = expr.Pos()
 
+	switch config.Name {
+	case constraintsPath + ".WithMessage", constraintsPath + ".WithCode":
+		return buildOverrideConstraintConfig(src, predicate, constraintFunc.Sel.Name, constraintCall)
+	}
+
+	return config, nil
+}
+
+// buildOverrideConstraintConfig builds ConstraintConfig for the constraint wrapped by a call to
+// WithMessage or WithCode, overriding the message or code of the violations it produces.
+func buildOverrideConstraintConfig(src valley.Source, predicate ast.Expr, funcName string, call *ast.CallExpr) (valley.ConstraintConfig, error) {
+	if len(call.Args) != 2 {
+		return valley.ConstraintConfig{}, errorOn(src, call.Pos(), "exactly two arguments should be passed to %s", funcName)
+	}
+
+	config, err := buildConstraintConfig(src, predicate, call.Args[0])
+	if err != nil {
+		return config, err
+	}
+
+	lit, ok := call.Args[1].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return config, errorOn(src, call.Args[1].Pos(), "value passed to %s should be a string literal", funcName)
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil || value == "" {
+		return config, errorOn(src, call.Args[1].Pos(), "value passed to %s should not be empty", funcName)
+	}
+
+	if funcName == "WithMessage" {
+		config.Message = value
+	} else {
+		config.Code = value
+	}
+
 	return config, nil
 }
 
@@ -331,22 +509,55 @@ func (n *callExprNode) Reverse() *callExprNode {
 	return prev
 }
 
-// collectConstraintsMethods looks through the methods in a given Source and extracts the first
-// method that looks like a constraints method. This allows the Constraint method to have any name.
-//
-// TODO: Having any name is not very useful, it's only useful if you can define more than one, and
-// have each constraints method generate a different validation function at the end.
-func collectConstraintsMethods(src valley.Source) map[string]valley.Method {
-	constraintsMethods := make(map[string]valley.Method)
+// collectConstraintsMethods looks through the methods in a given Source and extracts each method
+// that looks like a constraints method. Each type may have many constraints methods, and each one
+// will generate a different validation method (see ValidateMethodName). Methods are returned in the
+// order that they're declared in.
+func collectConstraintsMethods(src valley.Source) map[string][]valley.Method {
+	return collectConstraints(src, src.Methods, 1)
+}
 
-	for typeName, methods := range src.Methods {
+// hasPointerParam returns true if the first parameter of the given constraints function accepts a
+// pointer to the type it's for.
+func hasPointerParam(function valley.Method) bool {
+	if function.Params == nil || len(function.Params.List) == 0 {
+		return false
+	}
+
+	_, ok := function.Params.List[0].Type.(*ast.StarExpr)
+	return ok
+}
+
+// collectConstraintsFunctions looks through the functions in a given Source and extracts each
+// function that looks like a constraints function, i.e. one that accepts a value of the type being
+// configured, followed by a valley.Type.
+func collectConstraintsFunctions(src valley.Source) map[string][]valley.Method {
+	return collectConstraints(src, src.Functions, 2)
+}
+
+// collectConstraints extracts each of the given methods that accepts the given number of params,
+// the last of which is a valley.Type, and returns nothing.
+func collectConstraints(src valley.Source, typeMethods valley.Methods, numParams int) map[string][]valley.Method {
+	constraintsMethods := make(map[string][]valley.Method)
+
+	for typeName, methods := range typeMethods {
 		for _, method := range methods {
-			if method.Results != nil || method.Params == nil || len(method.Params.List) != 1 {
-				// Valley constraints methods don't return anything, and have one param.
+			if method.FileName != src.FileName {
+				// Imports are only known for the source file, so constraints can only be resolved
+				// for constraints methods that are defined in that file.
 				continue
 			}
 
-			param := method.Params.List[0]
+			if method.Results != nil || method.Params == nil || len(method.Params.List) != numParams {
+				// Valley constraints methods don't return anything, and have a set number of params.
+				continue
+			}
+
+			param := method.Params.List[numParams-1]
+			if len(param.Names) > 1 {
+				// e.g. `func(a, b valley.Type)`, which would be more params than we expect.
+				continue
+			}
 
 			selector, ok := param.Type.(*ast.SelectorExpr)
 			if !ok || selector.Sel.Name != "Type" {
@@ -358,13 +569,12 @@ func collectConstraintsMethods(src valley.Source) map[string]valley.Method {
 			selectorPkg := selector.X.(*ast.Ident)
 
 			imp, ok := findImportByName(src.Imports, selectorPkg.Name)
-			if !ok || imp.Path != importPath {
+			if !ok || imp.Path != valley.ImportPath {
 				// The type must come from our code!
 				continue
 			}
 
-			constraintsMethods[typeName] = method
-			break
+			constraintsMethods[typeName] = append(constraintsMethods[typeName], method)
 		}
 	}
 
@@ -384,47 +594,31 @@ func findImportByName(imports []valley.Import, name string) (valley.Import, bool
 
 // errorOn returns an error with the given message, in the given Source, at the given position.
 func errorOn(src valley.Source, pos token.Pos, message string, args ...interface{}) error {
-	return errors.New(messageOn(src, pos, message, args...))
+	return diagnosticOn(src, valley.SeverityError, pos, message, args...)
 }
 
-// warnOn prints a given warning message, in the given Source, at the given position.
-func warnOn(src valley.Source, pos token.Pos, message string, args ...interface{}) {
-	fmt.Printf("valley: %s\n", messageOn(src, pos, message, args...))
-}
-
-// messageOn returns a formatted message, in the given Source, at the given position.
-func messageOn(src valley.Source, pos token.Pos, message string, args ...interface{}) string {
-	position := src.FileSet.Position(pos)
-
-	srcPath, err := filepath.Abs(src.FileName)
-	if err != nil {
-		srcPath = src.FileName
+// warnOn reports a given warning message, in the given Source, at the given position. If warnings
+// are treated as errors, the warning is instead returned as an error.
+func warnOn(src valley.Source, options options, pos token.Pos, message string, args ...interface{}) error {
+	if options.strict {
+		return diagnosticOn(src, valley.SeverityError, pos, message, args...)
 	}
 
-	// This just won't do anything if it fails, and will either leave us with just the filename, or
-	// the full absolute path.
-	srcPath = pathRelativeToModuleRoot(srcPath)
-
-	args = append(args, position.Line, position.Column, srcPath)
+	options.reporter.Report(diagnosticOn(src, valley.SeverityWarning, pos, message, args...))
 
-	return fmt.Sprintf(message+" on line %d, col %d in '%s'", args...)
+	return nil
 }
 
-// pathRelativeToModuleRoot ...
-func pathRelativeToModuleRoot(in string) string {
-	cmd := exec.Command("go", "list", "-m", "-json")
-
-	bs, err := cmd.CombinedOutput()
-	if err != nil {
-		return in
-	}
-
-	var module valley.Module
+// diagnosticOn returns a Diagnostic with the given severity and message, in the given Source, at the
+// given position.
+func diagnosticOn(src valley.Source, severity valley.Severity, pos token.Pos, message string, args ...interface{}) valley.Diagnostic {
+	position := src.FileSet.Position(pos)
 
-	err = json.Unmarshal(bs, &module)
-	if err != nil {
-		return in
+	return valley.Diagnostic{
+		File:     position.Filename,
+		Line:     position.Line,
+		Column:   position.Column,
+		Severity: severity,
+		Message:  fmt.Sprintf(message, args...),
 	}
-
-	return strings.TrimPrefix(in, module.Dir+string(os.PathSeparator))
 }
@@ -3,12 +3,12 @@ package config
 import (
 	"flag"
 	"fmt"
-	"go/parser"
 	"go/token"
 	"io/ioutil"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/seeruk/valley"
 	"github.com/seeruk/valley/source"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,29 +35,149 @@ func TestBuildFromSource(t *testing.T) {
 		{name: "td12", desc: "should error if multiple chained method calls are invalid on 'Field'"},
 		{name: "td13", desc: "should ignore methods that don't look like constraints methods"},
 		{name: "td14", desc: "should ignore statements in a constraint method's body that are invalid"},
+		{name: "td15", desc: "should only use constraints methods from the source file, for types declared in any file"},
+		{name: "td16", desc: "should produce config for each constraints method on a type"},
+		{name: "td17", desc: "should produce config for constraints functions, including for types in other packages"},
+		{name: "td23", desc: "should produce config for fields of nested structs"},
+		{name: "td24", desc: "should produce config with the messages and codes given by WithMessage and WithCode"},
+		{name: "td25", desc: "should error if the value passed to WithMessage is not a string literal"},
+		{name: "td26", desc: "should error if two constraints methods on a type generate the same validation method"},
+		{name: "td27", desc: "should error if two constraints functions generate the same validation function"},
 	}
 
 	for _, tc := range tt {
 		inFile := fmt.Sprintf("./testdata/%s/testdata.go", tc.name)
 		outFile := fmt.Sprintf("./testdata/%s/testdata.txt", tc.name)
 
-		fileSet := token.NewFileSet()
-		file, err := parser.ParseFile(fileSet, inFile, nil, 0)
+		src, err := source.Read(token.NewFileSet(), inFile)
 		require.NoError(t, err)
 
-		src := source.Read(fileSet, file, inFile)
+		// Don't output things that will change each run.
+		spewer := spew.NewDefaultConfig()
+		spewer.DisablePointerAddresses = true
+		spewer.SortKeys = true
+
+		reporter := &recordingReporter{}
+
+		config, err := BuildFromSource(src, WithReporter(reporter))
+
+		actual := fmt.Sprintf("Description: %s\n\nConfig:\n\n%s\nError:\n\n%s\nDiagnostics:\n\n%s",
+			tc.desc,
+			spewer.Sdump(config),
+			spewer.Sdump(err),
+			spewer.Sdump(reporter.diagnostics),
+		)
+
+		if *update {
+			err := ioutil.WriteFile(outFile, []byte(actual), 0666)
+			require.NoError(t, err)
+		}
+
+		bs, err := ioutil.ReadFile(outFile)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(bs), actual)
+	}
+}
+
+func TestBuildFromSource_Strict(t *testing.T) {
+	src, err := source.Read(token.NewFileSet(), "./testdata/td06/testdata.go")
+	require.NoError(t, err)
+
+	t.Run("should error instead of reporting warnings", func(t *testing.T) {
+		reporter := &recordingReporter{}
+
+		_, err := BuildFromSource(src, WithReporter(reporter), WithStrict(true))
+		require.Error(t, err)
+		require.IsType(t, valley.Diagnostic{}, err)
+		assert.Equal(t, valley.SeverityError, err.(valley.Diagnostic).Severity)
+		assert.Equal(t, 17, err.(valley.Diagnostic).Line)
+		assert.Empty(t, reporter.diagnostics)
+	})
+
+	t.Run("should not error if there are no warnings", func(t *testing.T) {
+		src, err := source.Read(token.NewFileSet(), "./testdata/td01/testdata.go")
+		require.NoError(t, err)
+
+		_, err = BuildFromSource(src, WithStrict(true))
+		assert.NoError(t, err)
+	})
+}
+
+func TestBuildFromTags(t *testing.T) {
+	tt := []struct {
+		name    string
+		tagName string
+		desc    string
+	}{
+		{name: "td18", tagName: "valley", desc: "should produce config from rules in struct tags, following the alias in Valley's tag"},
+		{name: "td19", tagName: "constraints", desc: "should error if an unknown rule is used in a struct tag"},
+	}
+
+	for _, tc := range tt {
+		inFile := fmt.Sprintf("./testdata/%s/testdata.go", tc.name)
+		outFile := fmt.Sprintf("./testdata/%s/testdata.txt", tc.name)
+
+		src, err := source.Read(token.NewFileSet(), inFile)
+		require.NoError(t, err)
+
+		// Don't output things that will change each run.
+		spewer := spew.NewDefaultConfig()
+		spewer.DisablePointerAddresses = true
+		spewer.SortKeys = true
+
+		reporter := &recordingReporter{}
+
+		config, err := BuildFromTags(src, tc.tagName, WithReporter(reporter))
+
+		actual := fmt.Sprintf("Description: %s\n\nConfig:\n\n%s\nError:\n\n%s\nDiagnostics:\n\n%s",
+			tc.desc,
+			spewer.Sdump(config),
+			spewer.Sdump(err),
+			spewer.Sdump(reporter.diagnostics),
+		)
+
+		if *update {
+			err := ioutil.WriteFile(outFile, []byte(actual), 0666)
+			require.NoError(t, err)
+		}
+
+		bs, err := ioutil.ReadFile(outFile)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(bs), actual)
+	}
+}
+
+func TestBuildFromValidatorTags(t *testing.T) {
+	tt := []struct {
+		name string
+		desc string
+	}{
+		{name: "td20", desc: "should produce config from validator struct tags, warning about rules that can't be mapped"},
+	}
+
+	for _, tc := range tt {
+		inFile := fmt.Sprintf("./testdata/%s/testdata.go", tc.name)
+		outFile := fmt.Sprintf("./testdata/%s/testdata.txt", tc.name)
+
+		src, err := source.Read(token.NewFileSet(), inFile)
+		require.NoError(t, err)
 
 		// Don't output things that will change each run.
 		spewer := spew.NewDefaultConfig()
 		spewer.DisablePointerAddresses = true
 		spewer.SortKeys = true
 
-		config, err := BuildFromSource(src)
+		reporter := &recordingReporter{}
+
+		config, err := BuildFromValidatorTags(src, "validate", WithReporter(reporter))
 
-		actual := fmt.Sprintf("Description: %s\n\nConfig:\n\n%s\nError:\n\n%s",
+		actual := fmt.Sprintf("Description: %s\n\nConfig:\n\n%s\nError:\n\n%s\nDiagnostics:\n\n%s",
 			tc.desc,
 			spewer.Sdump(config),
 			spewer.Sdump(err),
+			spewer.Sdump(reporter.diagnostics),
 		)
 
 		if *update {
@@ -70,4 +190,40 @@ func TestBuildFromSource(t *testing.T) {
 
 		assert.Equal(t, string(bs), actual)
 	}
+
+	t.Run("should error instead of reporting warnings in strict mode", func(t *testing.T) {
+		src, err := source.Read(token.NewFileSet(), "./testdata/td20/testdata.go")
+		require.NoError(t, err)
+
+		_, err = BuildFromValidatorTags(src, "validate", WithStrict(true))
+		require.Error(t, err)
+		require.IsType(t, valley.Diagnostic{}, err)
+		assert.Equal(t, 14, err.(valley.Diagnostic).Line)
+	})
+}
+
+func TestValidateMethodName(t *testing.T) {
+	tt := []struct {
+		in  string
+		out string
+	}{
+		{in: "Constraints", out: "Validate"},
+		{in: "CreateConstraints", out: "ValidateCreate"},
+		{in: "Rules", out: "ValidateRules"},
+	}
+
+	for _, tc := range tt {
+		t.Run(fmt.Sprintf("should return %q for %q", tc.out, tc.in), func(t *testing.T) {
+			assert.Equal(t, tc.out, ValidateMethodName(tc.in))
+		})
+	}
+}
+
+// recordingReporter is a valley.Reporter that keeps hold of the Diagnostics it's given.
+type recordingReporter struct {
+	diagnostics []valley.Diagnostic
+}
+
+func (r *recordingReporter) Report(diagnostic valley.Diagnostic) {
+	r.diagnostics = append(r.diagnostics, diagnostic)
 }
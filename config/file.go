@@ -0,0 +1,383 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/seeruk/valley"
+)
+
+// File represents Config as it's written in a JSON file, which allows constraints to be declared
+// for types without touching their source. It mirrors Config, except that Go expressions (i.e. the
+// options passed to constraints, and predicates) are written as strings, e.g.:
+//
+//	{
+//	  "types": {
+//	    "User": [
+//	      {
+//	        "name": "Validate",
+//	        "fields": {
+//	          "Name": {
+//	            "constraints": [
+//	              {"name": "Required"},
+//	              {"name": "MaxLength", "opts": ["255"]}
+//	            ]
+//	          }
+//	        }
+//	      }
+//	    ]
+//	  }
+//	}
+//
+// Constraint names may be the full name of a constraint (e.g.
+// "github.com/seeruk/valley/validation/constraints.Required"), or just the name of one of the
+// built-in constraints (e.g. "Required").
+type File struct {
+	Types map[string][]FileTypeConfig `json:"types"`
+
+	path string
+}
+
+// FileTypeConfig is the TypeConfig for a type in a File. If Name is empty, it's "Validate". If it's
+// merged with TypeConfig from a constraints method, Receiver must match that method's receiver.
+type FileTypeConfig struct {
+	Name        string                     `json:"name,omitempty"`
+	Receiver    string                     `json:"receiver,omitempty"`
+	Function    bool                       `json:"function,omitempty"`
+	Pointer     bool                       `json:"pointer,omitempty"`
+	Constraints []FileConstraintConfig     `json:"constraints,omitempty"`
+	Fields      map[string]FileFieldConfig `json:"fields,omitempty"`
+}
+
+// FileFieldConfig is the FieldConfig for a field in a File.
+type FileFieldConfig struct {
+	Constraints []FileConstraintConfig `json:"constraints,omitempty"`
+	Elements    []FileConstraintConfig `json:"elements,omitempty"`
+	Keys        []FileConstraintConfig `json:"keys,omitempty"`
+}
+
+// FileConstraintConfig is the ConstraintConfig for a constraint in a File. Opts and When (i.e. the
+// predicate given to `When`) are Go expressions, which may use the type's receiver, and any packages
+// imported by the source file that the type is declared in. Message and Code override those of the
+// violations the constraint produces. Pos is where the constraint was declared (as "file:line:col"),
+// which is only set by ToFile, for information.
+type FileConstraintConfig struct {
+	Name    string   `json:"name"`
+	Opts    []string `json:"opts,omitempty"`
+	When    string   `json:"when,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Code    string   `json:"code,omitempty"`
+	Pos     string   `json:"pos,omitempty"`
+}
+
+// ReadFile reads a File from the JSON file at the given path.
+func ReadFile(path string) (File, error) {
+	var file File
+
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	err = json.Unmarshal(bs, &file)
+	if err != nil {
+		return file, valley.Diagnostic{
+			File:     path,
+			Severity: valley.SeverityError,
+			Message:  fmt.Sprintf("failed to parse config file: %v", err),
+		}
+	}
+
+	file.path = path
+
+	return file, nil
+}
+
+// TypeNames returns the names of all of the types that have configuration in this File, in order.
+func (f File) TypeNames() []string {
+	typeNames := make([]string, 0, len(f.Types))
+	for typeName := range f.Types {
+		typeNames = append(typeNames, typeName)
+	}
+
+	sort.Strings(typeNames)
+
+	return typeNames
+}
+
+// BuildFromFile builds Config for all types in the given File that are declared in the source file
+// of a given Source, so that the code generated for them can be written alongside it. Types that
+// are declared elsewhere are ignored. The options of each constraint, and predicates, are parsed as
+// Go expressions.
+//
+// Types from other packages (e.g. "url.URL") can't be configured in a File, as there's no source
+// file to write the code generated for them alongside; a constraints function must be used instead.
+func BuildFromFile(src valley.Source, file File) (valley.Config, error) {
+	config := valley.Config{
+		Types: make(map[string][]valley.TypeConfig),
+	}
+
+	for _, typeName := range file.TypeNames() {
+		if strings.Contains(typeName, ".") {
+			return config, valley.Diagnostic{
+				File:     file.path,
+				Severity: valley.SeverityError,
+				Message: fmt.Sprintf(
+					"type %q is from another package, which can't be configured in a config file, use a constraints function instead",
+					typeName,
+				),
+			}
+		}
+
+		s, ok := src.Structs[typeName]
+		if !ok || s.FileName != src.FileName {
+			continue
+		}
+
+		for _, fileTypeConfig := range file.Types[typeName] {
+			typeConfig, err := buildFileTypeConfig(src, file, typeName, fileTypeConfig)
+			if err != nil {
+				return config, err
+			}
+
+			config.Types[typeName] = append(config.Types[typeName], typeConfig)
+		}
+	}
+
+	return config, nil
+}
+
+// buildFileTypeConfig builds TypeConfig from the given FileTypeConfig.
+func buildFileTypeConfig(src valley.Source, file File, typeName string, fileTypeConfig FileTypeConfig) (valley.TypeConfig, error) {
+	config := valley.TypeConfig{
+		Name:     fileTypeConfig.Name,
+		Receiver: fileTypeConfig.Receiver,
+		Function: fileTypeConfig.Function,
+		Pointer:  fileTypeConfig.Pointer,
+		Fields:   make(map[string]valley.FieldConfig),
+	}
+
+	if config.Name == "" {
+		config.Name = ValidateMethodName("Constraints")
+	}
+
+	var err error
+
+	config.Constraints, err = buildFileConstraintConfigs(src, file, typeName, "", fileTypeConfig.Constraints)
+	if err != nil {
+		return config, err
+	}
+
+	fieldNames := make([]string, 0, len(fileTypeConfig.Fields))
+	for fieldName := range fileTypeConfig.Fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+
+	// Expressions are parsed in the same order each time, so their positions are always the same.
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		fileFieldConfig := fileTypeConfig.Fields[fieldName]
+
+		if _, err := src.FieldPath(typeName, fieldName); err != nil {
+			return config, fileErrorf(file, "invalid field %q on type %q: %v", fieldName, typeName, err)
+		}
+
+		var fieldConfig valley.FieldConfig
+
+		fieldConfig.Constraints, err = buildFileConstraintConfigs(src, file, typeName, fieldName, fileFieldConfig.Constraints)
+		if err != nil {
+			return config, err
+		}
+
+		fieldConfig.Elements, err = buildFileConstraintConfigs(src, file, typeName, fieldName, fileFieldConfig.Elements)
+		if err != nil {
+			return config, err
+		}
+
+		fieldConfig.Keys, err = buildFileConstraintConfigs(src, file, typeName, fieldName, fileFieldConfig.Keys)
+		if err != nil {
+			return config, err
+		}
+
+		config.Fields[fieldName] = fieldConfig
+	}
+
+	return config, nil
+}
+
+// buildFileConstraintConfigs builds ConstraintConfig from each of the given FileConstraintConfig.
+func buildFileConstraintConfigs(src valley.Source, file File, typeName, fieldName string, fileConfigs []FileConstraintConfig) ([]valley.ConstraintConfig, error) {
+	var configs []valley.ConstraintConfig
+
+	selector := typeName
+	if fieldName != "" {
+		selector += "." + fieldName
+	}
+
+	for _, fileConfig := range fileConfigs {
+		if fileConfig.Name == "" {
+			return nil, fileErrorf(file, "constraint on %s has no name", selector)
+		}
+
+		config := valley.ConstraintConfig{
+			Name:    fileConfig.Name,
+			Message: fileConfig.Message,
+			Code:    fileConfig.Code,
+		}
+
+		if !strings.Contains(config.Name, ".") {
+			config.Name = fmt.Sprintf("%s.%s", constraintsPath, config.Name)
+		}
+
+		for _, opt := range fileConfig.Opts {
+			expr, err := parseFileExpr(src, opt)
+			if err != nil {
+				return nil, fileErrorf(file, "invalid option %q for %s's %q constraint: %v", opt, selector, fileConfig.Name, err)
+			}
+
+			config.Opts = append(config.Opts, expr)
+		}
+
+		if fileConfig.When != "" {
+			expr, err := parseFileExpr(src, fileConfig.When)
+			if err != nil {
+				return nil, fileErrorf(file, "invalid predicate %q for %s's %q constraint: %v", fileConfig.When, selector, fileConfig.Name, err)
+			}
+
+			config.Predicate = expr
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+// ToFile converts the given Config, built from the given Source, into a File, rendering Go
+// expressions back to source code. This is useful for seeing what Valley understood from the source,
+// and as File can be written as JSON, a starting point for a config file.
+func ToFile(src valley.Source, config valley.Config) (File, error) {
+	file := File{
+		Types: make(map[string][]FileTypeConfig, len(config.Types)),
+	}
+
+	for typeName, typeConfigs := range config.Types {
+		for _, typeConfig := range typeConfigs {
+			fileTypeConfig := FileTypeConfig{
+				Name:     typeConfig.Name,
+				Receiver: typeConfig.Receiver,
+				Function: typeConfig.Function,
+				Pointer:  typeConfig.Pointer,
+			}
+
+			var err error
+
+			fileTypeConfig.Constraints, err = toFileConstraintConfigs(src, typeConfig.Constraints)
+			if err != nil {
+				return file, err
+			}
+
+			if len(typeConfig.Fields) > 0 {
+				fileTypeConfig.Fields = make(map[string]FileFieldConfig, len(typeConfig.Fields))
+			}
+
+			for fieldName, fieldConfig := range typeConfig.Fields {
+				var fileFieldConfig FileFieldConfig
+
+				fileFieldConfig.Constraints, err = toFileConstraintConfigs(src, fieldConfig.Constraints)
+				if err != nil {
+					return file, err
+				}
+
+				fileFieldConfig.Elements, err = toFileConstraintConfigs(src, fieldConfig.Elements)
+				if err != nil {
+					return file, err
+				}
+
+				fileFieldConfig.Keys, err = toFileConstraintConfigs(src, fieldConfig.Keys)
+				if err != nil {
+					return file, err
+				}
+
+				fileTypeConfig.Fields[fieldName] = fileFieldConfig
+			}
+
+			file.Types[typeName] = append(file.Types[typeName], fileTypeConfig)
+		}
+	}
+
+	return file, nil
+}
+
+// toFileConstraintConfigs converts each of the given ConstraintConfig into FileConstraintConfig.
+func toFileConstraintConfigs(src valley.Source, configs []valley.ConstraintConfig) ([]FileConstraintConfig, error) {
+	var fileConfigs []FileConstraintConfig
+
+	for _, config := range configs {
+		fileConfig := FileConstraintConfig{
+			Name:    config.Name,
+			Message: config.Message,
+			Code:    config.Code,
+		}
+
+		for _, opt := range config.Opts {
+			expr, err := printFileExpr(src, opt)
+			if err != nil {
+				return nil, err
+			}
+
+			fileConfig.Opts = append(fileConfig.Opts, expr)
+		}
+
+		if config.Predicate != nil {
+			expr, err := printFileExpr(src, config.Predicate)
+			if err != nil {
+				return nil, err
+			}
+
+			fileConfig.When = expr
+		}
+
+		if config.Pos.IsValid() {
+			fileConfig.Pos = src.FileSet.Position(config.Pos).String()
+		}
+
+		fileConfigs = append(fileConfigs, fileConfig)
+	}
+
+	return fileConfigs, nil
+}
+
+// printFileExpr renders the given Go expression as source code, to be written to a File.
+func printFileExpr(src valley.Source, expr ast.Expr) (string, error) {
+	buf := &bytes.Buffer{}
+
+	err := printer.Fprint(buf, src.FileSet, expr)
+	if err != nil {
+		return "", fmt.Errorf("failed to render expression: %v", err)
+	}
+
+	return buf.String(), nil
+}
+
+// parseFileExpr parses a Go expression read from a File.
+func parseFileExpr(src valley.Source, expr string) (ast.Expr, error) {
+	return parser.ParseExprFrom(src.FileSet, "", expr, 0)
+}
+
+// fileErrorf returns an error with the given message, in the given File.
+func fileErrorf(file File, message string, args ...interface{}) error {
+	return valley.Diagnostic{
+		File:     file.path,
+		Severity: valley.SeverityError,
+		Message:  fmt.Sprintf(message, args...),
+	}
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFile(t *testing.T) {
+	t.Run("should read config from a JSON file", func(t *testing.T) {
+		file, err := ReadFile("./testdata/td21/constraints.json")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Subject", "Unknown"}, file.TypeNames())
+		assert.Len(t, file.Types["Subject"], 2)
+	})
+
+	t.Run("should error if the file doesn't exist", func(t *testing.T) {
+		_, err := ReadFile("./testdata/nonexistent.json")
+		assert.Error(t, err)
+	})
+
+	t.Run("should error if the file isn't valid JSON", func(t *testing.T) {
+		_, err := ReadFile("./testdata/td21/testdata.go")
+		require.Error(t, err)
+		require.IsType(t, valley.Diagnostic{}, err)
+		assert.Equal(t, "./testdata/td21/testdata.go", err.(valley.Diagnostic).File)
+	})
+}
+
+func TestBuildFromFile(t *testing.T) {
+	tt := []struct {
+		name string
+		desc string
+	}{
+		{name: "td21", desc: "should produce config from a file for types declared in the source file"},
+		{name: "td22", desc: "should error if an option in a file is not a valid Go expression"},
+	}
+
+	for _, tc := range tt {
+		inFile := fmt.Sprintf("./testdata/%s/testdata.go", tc.name)
+		configFile := fmt.Sprintf("./testdata/%s/constraints.json", tc.name)
+		outFile := fmt.Sprintf("./testdata/%s/testdata.txt", tc.name)
+
+		src, err := source.Read(token.NewFileSet(), inFile)
+		require.NoError(t, err)
+
+		file, err := ReadFile(configFile)
+		require.NoError(t, err)
+
+		// Don't output things that will change each run.
+		spewer := spew.NewDefaultConfig()
+		spewer.DisablePointerAddresses = true
+		spewer.SortKeys = true
+
+		config, err := BuildFromFile(src, file)
+
+		actual := fmt.Sprintf("Description: %s\n\nConfig:\n\n%s\nError:\n\n%s",
+			tc.desc,
+			spewer.Sdump(config),
+			spewer.Sdump(err),
+		)
+
+		if *update {
+			err := ioutil.WriteFile(outFile, []byte(actual), 0666)
+			require.NoError(t, err)
+		}
+
+		bs, err := ioutil.ReadFile(outFile)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(bs), actual)
+	}
+
+	t.Run("should error if a field doesn't exist on the type", func(t *testing.T) {
+		src, err := source.Read(token.NewFileSet(), "./testdata/td21/testdata.go")
+		require.NoError(t, err)
+
+		file := File{Types: map[string][]FileTypeConfig{
+			"Subject": {{Fields: map[string]FileFieldConfig{
+				"Nonexistent": {Constraints: []FileConstraintConfig{{Name: "Required"}}},
+			}}},
+		}}
+
+		_, err = BuildFromFile(src, file)
+		assert.EqualError(t, err, `invalid field "Nonexistent" on type "Subject": field "Nonexistent" does not exist in Go source`)
+	})
+
+	t.Run("should error if a type is from another package", func(t *testing.T) {
+		src, err := source.Read(token.NewFileSet(), "./testdata/td21/testdata.go")
+		require.NoError(t, err)
+
+		file := File{Types: map[string][]FileTypeConfig{
+			"url.URL": {{Name: "ValidateURL", Function: true}},
+		}}
+
+		_, err = BuildFromFile(src, file)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `type "url.URL" is from another package`)
+	})
+}
+
+func TestToFile(t *testing.T) {
+	src, err := source.Read(token.NewFileSet(), "./testdata/td21/testdata.go")
+	require.NoError(t, err)
+
+	file, err := ReadFile("./testdata/td21/constraints.json")
+	require.NoError(t, err)
+
+	fileConfig, err := BuildFromFile(src, file)
+	require.NoError(t, err)
+
+	sourceConfig, err := BuildFromSource(src)
+	require.NoError(t, err)
+
+	t.Run("should render expressions as source code", func(t *testing.T) {
+		actual, err := ToFile(src, fileConfig)
+		require.NoError(t, err)
+		require.Len(t, actual.Types["Subject"], 2)
+
+		constraint := actual.Types["Subject"][0].Constraints[0]
+		assert.Equal(t, "github.com/seeruk/valley/validation/constraints.MutuallyExclusive", constraint.Name)
+		assert.Equal(t, []string{"s.Name", "s.Tags"}, constraint.Opts)
+		assert.Equal(t, "s.Admin", constraint.When)
+		assert.Empty(t, constraint.Pos)
+
+		constraint = actual.Types["Subject"][0].Fields["Name"].Constraints[0]
+		assert.Equal(t, "name is too long", constraint.Message)
+		assert.Equal(t, "name_too_long", constraint.Code)
+	})
+
+	t.Run("should include the position each constraint was declared at", func(t *testing.T) {
+		actual, err := ToFile(src, sourceConfig)
+		require.NoError(t, err)
+		require.Len(t, actual.Types["Subject"], 1)
+
+		constraint := actual.Types["Subject"][0].Fields["Name"].Constraints[0]
+		assert.Equal(t, "./testdata/td21/testdata.go:18:30", constraint.Pos)
+	})
+
+	t.Run("should produce a File that can be built from again", func(t *testing.T) {
+		actual, err := ToFile(src, fileConfig)
+		require.NoError(t, err)
+
+		config, err := BuildFromFile(src, actual)
+		require.NoError(t, err)
+
+		roundTripped, err := ToFile(src, config)
+		require.NoError(t, err)
+		assert.Equal(t, actual, roundTripped)
+	})
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/seeruk/valley"
+)
+
+// Merge combines the given Config into one. Configuration for validation methods of the same name
+// on the same type is combined, so that constraints may come from many places (e.g. constraints
+// methods and struct tags) but still be generated as one method. An error is returned if they give
+// different receivers for the same validation method, as expressions written against one receiver
+// wouldn't compile against the other.
+func Merge(configs ...valley.Config) (valley.Config, error) {
+	merged := valley.Config{
+		Types: make(map[string][]valley.TypeConfig),
+	}
+
+	for _, config := range configs {
+		for typeName, typeConfigs := range config.Types {
+			for _, typeConfig := range typeConfigs {
+				typeConfigs, err := mergeTypeConfig(typeName, merged.Types[typeName], typeConfig)
+				if err != nil {
+					return merged, err
+				}
+
+				merged.Types[typeName] = typeConfigs
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// mergeTypeConfig adds the given TypeConfig to the given set of TypeConfig for a type, combining it
+// with any existing TypeConfig for a validation method of the same name.
+func mergeTypeConfig(typeName string, existing []valley.TypeConfig, typeConfig valley.TypeConfig) ([]valley.TypeConfig, error) {
+	for i, existingConfig := range existing {
+		if existingConfig.Name != typeConfig.Name || existingConfig.Function != typeConfig.Function {
+			continue
+		}
+
+		switch {
+		case existingConfig.Receiver == "":
+			existingConfig.Receiver = typeConfig.Receiver
+		case typeConfig.Receiver != "" && typeConfig.Receiver != existingConfig.Receiver:
+			return existing, fmt.Errorf(
+				"%s.%s is configured with different receivers, %q and %q, which must match",
+				typeName, existingConfig.Name, existingConfig.Receiver, typeConfig.Receiver,
+			)
+		}
+
+		existingConfig.Pointer = existingConfig.Pointer || typeConfig.Pointer
+
+		existingConfig.Constraints = concatConstraints(existingConfig.Constraints, typeConfig.Constraints)
+
+		fields := make(map[string]valley.FieldConfig, len(existingConfig.Fields))
+		for fieldName, fieldConfig := range existingConfig.Fields {
+			fields[fieldName] = fieldConfig
+		}
+
+		for fieldName, fieldConfig := range typeConfig.Fields {
+			existingField := fields[fieldName]
+			existingField.Constraints = concatConstraints(existingField.Constraints, fieldConfig.Constraints)
+			existingField.Elements = concatConstraints(existingField.Elements, fieldConfig.Elements)
+			existingField.Keys = concatConstraints(existingField.Keys, fieldConfig.Keys)
+			fields[fieldName] = existingField
+		}
+
+		existingConfig.Fields = fields
+		existing[i] = existingConfig
+
+		return existing, nil
+	}
+
+	return append(existing, typeConfig), nil
+}
+
+// concatConstraints returns a new slice containing the ConstraintConfig in a, followed by those in b,
+// so that neither of the given slices are modified when Config is merged.
+func concatConstraints(a, b []valley.ConstraintConfig) []valley.ConstraintConfig {
+	if len(b) == 0 {
+		return a
+	}
+
+	result := make([]valley.ConstraintConfig, 0, len(a)+len(b))
+	result = append(result, a...)
+
+	return append(result, b...)
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/seeruk/valley"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	required := valley.ConstraintConfig{Name: "Required"}
+	maxLength := valley.ConstraintConfig{Name: "MaxLength"}
+
+	t.Run("should combine config for methods of the same name on the same type", func(t *testing.T) {
+		a := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "Validate", Fields: map[string]valley.FieldConfig{
+				"Name": {Constraints: []valley.ConstraintConfig{required}},
+			}}},
+		}}
+
+		b := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "Validate", Receiver: "s", Fields: map[string]valley.FieldConfig{
+				"Name": {Constraints: []valley.ConstraintConfig{maxLength}},
+				"ID":   {Constraints: []valley.ConstraintConfig{required}},
+			}}},
+		}}
+
+		merged, err := Merge(a, b)
+		require.NoError(t, err)
+		require.Len(t, merged.Types["Subject"], 1)
+
+		typeConfig := merged.Types["Subject"][0]
+		assert.Equal(t, "s", typeConfig.Receiver)
+		assert.Equal(t, []valley.ConstraintConfig{required, maxLength}, typeConfig.Fields["Name"].Constraints)
+		assert.Equal(t, []valley.ConstraintConfig{required}, typeConfig.Fields["ID"].Constraints)
+
+		// The given config should be left untouched.
+		assert.Len(t, a.Types["Subject"][0].Fields, 1)
+		assert.Len(t, a.Types["Subject"][0].Fields["Name"].Constraints, 1)
+	})
+
+	t.Run("should keep config for differently named methods separate", func(t *testing.T) {
+		a := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "Validate"}},
+		}}
+
+		b := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "ValidateCreate"}},
+			"Other":   {{Name: "Validate"}},
+		}}
+
+		merged, err := Merge(a, b)
+		require.NoError(t, err)
+		assert.Len(t, merged.Types["Subject"], 2)
+		assert.Len(t, merged.Types["Other"], 1)
+	})
+
+	t.Run("should keep pointer parameters of functions", func(t *testing.T) {
+		a := valley.Config{Types: map[string][]valley.TypeConfig{
+			"url.URL": {{Name: "ValidateURL", Function: true, Pointer: true}},
+		}}
+
+		b := valley.Config{Types: map[string][]valley.TypeConfig{
+			"url.URL": {{Name: "ValidateURL", Function: true, Fields: map[string]valley.FieldConfig{
+				"Host": {Constraints: []valley.ConstraintConfig{required}},
+			}}},
+		}}
+
+		merged, err := Merge(a, b)
+		require.NoError(t, err)
+		require.Len(t, merged.Types["url.URL"], 1)
+		assert.True(t, merged.Types["url.URL"][0].Pointer)
+		assert.Len(t, merged.Types["url.URL"][0].Fields, 1)
+	})
+
+	t.Run("should keep config for methods and functions of the same name separate", func(t *testing.T) {
+		a := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "ValidateSubject"}},
+		}}
+
+		b := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "ValidateSubject", Function: true}},
+		}}
+
+		merged, err := Merge(a, b)
+		require.NoError(t, err)
+		assert.Len(t, merged.Types["Subject"], 2)
+	})
+	t.Run("should error if receivers of the same method differ", func(t *testing.T) {
+		a := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "Validate", Receiver: "sub"}},
+		}}
+
+		b := valley.Config{Types: map[string][]valley.TypeConfig{
+			"Subject": {{Name: "Validate", Receiver: "s"}},
+		}}
+
+		_, err := Merge(a, b)
+		assert.Error(t, err)
+	})
+}
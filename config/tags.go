@@ -0,0 +1,388 @@
+package config
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"github.com/seeruk/valley"
+)
+
+// constraintsPath is the import path of Valley's built-in constraints, which struct tag rules map
+// on to.
+const constraintsPath = valley.ImportPath + "/validation/constraints"
+
+// aliasTagName is the name of the struct tag that Valley reads field aliases from by default. When
+// constraints are read from this tag, it's first element is the alias, rather than a rule.
+const aliasTagName = "valley"
+
+// Possible tagArgs values.
+const (
+	tagArgsNone tagArgs = iota
+	tagArgsOptionalString
+	tagArgsExpr
+	tagArgsString
+	tagArgsValue
+	tagArgsValues
+)
+
+// tagArgs describes the arguments that a struct tag rule accepts, and how they're turned into Go
+// expressions to pass to a constraint.
+type tagArgs int
+
+// tagRule describes how a rule in a struct tag maps on to one of Valley's built-in constraints.
+type tagRule struct {
+	constraint string
+	args       tagArgs
+}
+
+// tagRules is the set of rules that may be used in struct tags, keyed by the rule's name.
+var tagRules = map[string]tagRule{
+	"equals":             {constraint: "Equals", args: tagArgsValue},
+	"length":             {constraint: "Length", args: tagArgsExpr},
+	"max":                {constraint: "Max", args: tagArgsExpr},
+	"max_length":         {constraint: "MaxLength", args: tagArgsExpr},
+	"min":                {constraint: "Min", args: tagArgsExpr},
+	"min_length":         {constraint: "MinLength", args: tagArgsExpr},
+	"nil":                {constraint: "Nil"},
+	"not_empty":          {constraint: "NotEmpty"},
+	"not_equals":         {constraint: "NotEquals", args: tagArgsValue},
+	"not_nil":            {constraint: "NotNil"},
+	"one_of":             {constraint: "OneOf", args: tagArgsValues},
+	"optional":           {constraint: "Optional"},
+	"regexp":             {constraint: "RegexpString", args: tagArgsString},
+	"required":           {constraint: "Required"},
+	"time_string_after":  {constraint: "TimeStringAfter", args: tagArgsString},
+	"time_string_before": {constraint: "TimeStringBefore", args: tagArgsString},
+	"valid":              {constraint: "Valid", args: tagArgsOptionalString},
+}
+
+// BuildFromTags builds Config for all structs declared in the source file of a given Source, by
+// reading rules from the struct tag with the given name on each of their fields. Rules are comma
+// separated, and may be given an argument after an equals sign, e.g.:
+//
+//	Email string `valley:"email,required,max_length=255"`
+//
+// When reading Valley's own tag ("valley"), the first element is the field's alias (as it is when
+// generating code), so the rules follow it. Any other tag contains only rules, e.g.:
+//
+//	Email string `constraints:"required,max_length=255"`
+//
+// Each rule maps on to one of the built-in constraints (e.g. "max_length" is MaxLength). Arguments
+// are Go expressions, except for string values, which don't need to be quoted; rules that accept
+// many values (i.e. "one_of") separate them with spaces. Commas in arguments must be escaped with a
+// backslash (written "\\," in the quoted struct tag), as they otherwise separate rules. Each struct
+// with at least one rule produces configuration for a Validate method, which may be merged with
+// other Config using Merge.
+func BuildFromTags(src valley.Source, tagName string, opts ...Option) (valley.Config, error) {
+	options := options{
+		reporter: valley.DiscardReporter,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	config := valley.Config{
+		Types: make(map[string][]valley.TypeConfig),
+	}
+
+	for _, structName := range src.StructNames {
+		s, ok := src.Structs[structName]
+		if !ok || s.FileName != src.FileName || s.Node == nil {
+			// Only structs declared in the source file will have their tags read, as the code
+			// generated for them will be written alongside it.
+			continue
+		}
+
+		typeConfig, err := buildTagsTypeConfig(src, options, s, tagName)
+		if err != nil {
+			return config, err
+		}
+
+		if len(typeConfig.Fields) > 0 {
+			config.Types[structName] = append(config.Types[structName], typeConfig)
+		}
+	}
+
+	return config, nil
+}
+
+// buildTagsTypeConfig builds TypeConfig for a single struct by reading the struct tag with the given
+// name on each of it's fields.
+func buildTagsTypeConfig(src valley.Source, options options, s valley.Struct, tagName string) (valley.TypeConfig, error) {
+	config := valley.TypeConfig{
+		Name:   ValidateMethodName("Constraints"),
+		Fields: make(map[string]valley.FieldConfig),
+	}
+
+	for _, field := range taggedFields(s.Node, "") {
+		rules, ok, err := readTagRules(field.node.Tag.Value, tagName)
+		if err != nil {
+			return config, errorOn(src, field.node.Tag.Pos(), "%v", err)
+		}
+
+		if !ok || len(rules) == 0 {
+			continue
+		}
+
+		for _, name := range field.names {
+			value, err := taggedFieldValue(src, s.Name, name, field.node.Tag.Pos())
+			if err != nil {
+				return config, err
+			}
+
+			var fieldConfig valley.FieldConfig
+
+			for _, rule := range rules {
+				constraintConfig, err := buildTagConstraintConfig(src, value, rule, field.node.Tag.Pos())
+				if err != nil {
+					return config, err
+				}
+
+				fieldConfig.Constraints = append(fieldConfig.Constraints, constraintConfig)
+			}
+
+			config.Fields[name] = fieldConfig
+		}
+	}
+
+	return config, nil
+}
+
+// taggedField is a field with a struct tag, found by taggedFields.
+type taggedField struct {
+	node  *ast.Field
+	names []string
+}
+
+// taggedFields returns each field that has a struct tag in the given struct, along with the names
+// of the fields it declares, prefixed by the given prefix. Fields of anonymous inline structs (or
+// pointers to them) are included too, using the path to them as their name (e.g. "Meta.Owner"), as
+// they can't have validation methods of their own.
+func taggedFields(node *ast.StructType, prefix string) []taggedField {
+	var fields []taggedField
+
+	for _, field := range node.Fields.List {
+		names := structFieldNames(field)
+		for i, name := range names {
+			names[i] = prefix + name
+		}
+
+		if field.Tag != nil {
+			fields = append(fields, taggedField{node: field, names: names})
+		}
+
+		typ := field.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+
+		if inline, ok := typ.(*ast.StructType); ok {
+			for _, name := range names {
+				fields = append(fields, taggedFields(inline, name+".")...)
+			}
+		}
+	}
+
+	return fields
+}
+
+// taggedFieldValue returns the Value of the field with the given name (which may be a path through
+// anonymous inline structs) on the struct with the given name.
+func taggedFieldValue(src valley.Source, structName, fieldName string, pos token.Pos) (valley.Value, error) {
+	values, err := src.FieldPath(structName, fieldName)
+	if err != nil {
+		return valley.Value{}, errorOn(src, pos, "%v", err)
+	}
+
+	return values[len(values)-1], nil
+}
+
+// readTagRules returns the rules found in the struct tag with the given name, in the given raw
+// struct tag (as it's written in the source, i.e. quoted). If the struct tag isn't present, false
+// is returned.
+func readTagRules(rawTag string, tagName string) ([]string, bool, error) {
+	tag, err := strconv.Unquote(rawTag)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse struct tag: %v", err)
+	}
+
+	parsedTags, err := structtag.Parse(tag)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse struct tag: %q: %v", tag, err)
+	}
+
+	parsedTag, err := parsedTags.Get(tagName)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	var rules []string
+	for i, rule := range splitTagRules(parsedTag.Value()) {
+		if i == 0 && tagName == aliasTagName {
+			continue
+		}
+
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, true, nil
+}
+
+// splitTagRules splits the given (unquoted) struct tag value into rules, separated by commas. A
+// comma that's escaped with a backslash is part of a rule instead. Other backslashes are left as
+// they are, so that they may still be used in arguments, e.g. in regular expressions.
+func splitTagRules(value string) []string {
+	var rules []string
+	var rule strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value) && value[i+1] == ',':
+			rule.WriteByte(',')
+			i++
+		case value[i] == ',':
+			rules = append(rules, rule.String())
+			rule.Reset()
+		default:
+			rule.WriteByte(value[i])
+		}
+	}
+
+	return append(rules, rule.String())
+}
+
+// structFieldNames returns the names of the fields declared by the given struct field. Embedded
+// fields are named after their type.
+func structFieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		if name, ok := valley.EmbeddedFieldName(field.Type); ok {
+			return []string{name.Name}
+		}
+	}
+
+	names := make([]string, 0, len(field.Names))
+	for _, name := range field.Names {
+		names = append(names, name.Name)
+	}
+
+	return names
+}
+
+// buildTagConstraintConfig builds ConstraintConfig for a single rule read from a struct tag on the
+// given field.
+func buildTagConstraintConfig(src valley.Source, field valley.Value, rule string, pos token.Pos) (valley.ConstraintConfig, error) {
+	var config valley.ConstraintConfig
+
+	name, arg := rule, ""
+	hasArg := false
+
+	if i := strings.Index(rule, "="); i > -1 {
+		name, arg, hasArg = rule[:i], rule[i+1:], true
+	}
+
+	tr, ok := tagRules[name]
+	if !ok {
+		return config, errorOn(src, pos, "unknown rule %q in struct tag on field %q, expected one of: %s",
+			name, field.Name, strings.Join(tagRuleNames(), ", "))
+	}
+
+	if hasArg && tr.args == tagArgsNone {
+		return config, errorOn(src, pos, "rule %q in struct tag on field %q does not accept an argument", name, field.Name)
+	}
+
+	if !hasArg && tr.args != tagArgsNone && tr.args != tagArgsOptionalString {
+		return config, errorOn(src, pos, "rule %q in struct tag on field %q requires an argument", name, field.Name)
+	}
+
+	var args []string
+
+	switch {
+	case !hasArg:
+		// No arguments.
+	case tr.args == tagArgsValue && isStringValue(field):
+		args = []string{strconv.Quote(arg)}
+	case tr.args == tagArgsValues:
+		for _, value := range strings.Fields(arg) {
+			if isStringValue(field) {
+				value = strconv.Quote(value)
+			}
+
+			args = append(args, value)
+		}
+	case tr.args == tagArgsString || tr.args == tagArgsOptionalString:
+		args = []string{strconv.Quote(arg)}
+	default:
+		args = []string{arg}
+	}
+
+	config, err := buildTagConstraint(src, pos, tr.constraint, args)
+	if err != nil {
+		return config, errorOn(src, pos, "%v for rule %q in struct tag on field %q", err, name, field.Name)
+	}
+
+	return config, nil
+}
+
+// buildTagConstraint builds ConstraintConfig for the built-in constraint with the given name, by
+// parsing each of the given arguments (read from a struct tag) as a Go expression.
+func buildTagConstraint(src valley.Source, pos token.Pos, constraint string, args []string) (valley.ConstraintConfig, error) {
+	config := valley.ConstraintConfig{
+		Name: fmt.Sprintf("%s.%s", constraintsPath, constraint),
+		Pos:  pos,
+	}
+
+	for _, arg := range args {
+		expr, err := parser.ParseExprFrom(src.FileSet, "", arg, 0)
+		if err != nil {
+			return config, fmt.Errorf("invalid argument %q", arg)
+		}
+
+		config.Opts = append(config.Opts, expr)
+	}
+
+	return config, nil
+}
+
+// isStringValue returns true if the given value is a string (or a pointer to one), in which case
+// arguments in struct tags for it's value don't need to be quoted.
+func isStringValue(value valley.Value) bool {
+	if value.ResolvedType != nil {
+		typ := value.ResolvedType.Underlying()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem().Underlying()
+		}
+
+		basic, ok := typ.(*types.Basic)
+		return ok && basic.Info()&types.IsString != 0
+	}
+
+	typ := value.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	ident, ok := typ.(*ast.Ident)
+	return ok && ident.Name == "string"
+}
+
+// tagRuleNames returns the names of all rules that may be used in struct tags, in order.
+func tagRuleNames() []string {
+	names := make([]string, 0, len(tagRules))
+	for name := range tagRules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTagRules(t *testing.T) {
+	tt := []struct {
+		in  string
+		out []string
+	}{
+		{in: "required,max_length=255", out: []string{"required", "max_length=255"}},
+		{in: `regexp=^[a\,b]+$,required`, out: []string{"regexp=^[a,b]+$", "required"}},
+		{in: `regexp=^\d+$`, out: []string{`regexp=^\d+$`}},
+		{in: `one_of=a\,b c,`, out: []string{"one_of=a,b c", ""}},
+	}
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.out, splitTagRules(tc.in), tc.in)
+	}
+}
@@ -0,0 +1,12 @@
+package td15
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Constraints is a valley constraints method for a type declared in another file, used for testing
+// source reading functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.SomeText).Constraints(constraints.Required())
+}
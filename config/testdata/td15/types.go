@@ -0,0 +1,22 @@
+package td15
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	SomeText string `json:"some_text"`
+}
+
+// SecondarySubject is a type used for testing source reading functionality.
+type SecondarySubject struct {
+	SomeText string `json:"some_text"`
+}
+
+// Constraints is a valley constraints method in a file other than the one being read, which should
+// not be used to build configuration.
+func (s SecondarySubject) Constraints(t valley.Type) {
+	t.Field(s.SomeText).Constraints(constraints.Required())
+}
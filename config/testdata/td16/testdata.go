@@ -0,0 +1,22 @@
+package td16
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateConstraints is a valley constraints method used for testing source reading functionality.
+func (s Subject) CreateConstraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+}
+
+// UpdateConstraints is a valley constraints method used for testing source reading functionality.
+func (sub Subject) UpdateConstraints(t valley.Type) {
+	t.Field(sub.ID).Constraints(constraints.Required())
+}
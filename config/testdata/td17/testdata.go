@@ -0,0 +1,29 @@
+package td17
+
+import (
+	"net/url"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	Name string `json:"name"`
+}
+
+// SubjectConstraints is a valley constraints function used for testing source reading functionality.
+func SubjectConstraints(s Subject, t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+}
+
+// URLConstraints is a valley constraints function used for testing source reading functionality.
+func URLConstraints(u *url.URL, t valley.Type) {
+	t.Field(u.Host).Constraints(constraints.Required())
+}
+
+// NotConstraints is a function that doesn't look like a constraints function, so it is ignored.
+func NotConstraints(s Subject, t valley.Type) error {
+	t.Field(s.Name).Constraints(constraints.Required())
+	return nil
+}
@@ -0,0 +1,29 @@
+package td18
+
+// Subject is a type used for testing building config from struct tags.
+type Subject struct {
+	Embedded `valley:",valid"`
+
+	Email     string  `json:"email" valley:"email,required,max_length=255"`
+	Role      string  `valley:"role,one_of=admin user"`
+	Age       int     `valley:"age,min=18,equals=21"`
+	Nick      *string `valley:"nick,not_equals=bob"`
+	Before    string  `valley:",time_string_before=2020-01-01T00:00:00Z"`
+	Code      string  `valley:"code,regexp=^[a\\,b]+$,required"`
+	Size      string  `valley:"size,one_of=s\\,m l"`
+	Untagged  string
+	AliasOnly string `valley:"alias_only"`
+	Meta      struct {
+		Owner string `valley:"owner,required"`
+	} `valley:"meta"`
+}
+
+// Embedded is a type used for testing building config from struct tags.
+type Embedded struct {
+	Levels []int `valley:"levels,length=3"`
+}
+
+// NoRules is a type used for testing that structs without rules in their struct tags are skipped.
+type NoRules struct {
+	Name string `valley:"name"`
+}
@@ -0,0 +1,6 @@
+package td19
+
+// Subject is a type used for testing building config from struct tags.
+type Subject struct {
+	Name string `constraints:"required,maxlength=10"`
+}
@@ -0,0 +1,18 @@
+package td20
+
+// Subject is a type used for testing building config from validator struct tags.
+type Subject struct {
+	Email    string            `validate:"required,max=255,email"`
+	ID       string            `validate:"omitempty,uuid"`
+	Name     *string           `validate:"min=1,max=10"`
+	Age      int               `validate:"gte=18,lte=130,ne=99"`
+	Code     string            `validate:"len=3,eq=abc"`
+	Role     string            `validate:"oneof=admin user 'super user'"`
+	Level    int               `validate:"oneof=1 2 3"`
+	Tags     []string          `validate:"max=10,dive,required,min=2"`
+	Labels   map[string]string `validate:"dive,keys,required,endkeys,max=20"`
+	Password string            `validate:"required,eqfield=Confirm,required|email"`
+	Matrix   [][]int           `validate:"dive,dive,min=1"`
+	Ignored  string            `validate:"-"`
+	Confirm  string
+}
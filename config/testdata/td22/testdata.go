@@ -0,0 +1,19 @@
+package td22
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing building config from a file.
+type Subject struct {
+	Name  string            `json:"name"`
+	Tags  []string          `json:"tags"`
+	Attrs map[string]string `json:"attrs"`
+	Admin bool              `json:"admin"`
+}
+
+// Constraints is a valley constraints method used for testing building config from a file.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+}
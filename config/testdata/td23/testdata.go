@@ -0,0 +1,22 @@
+package td23
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	Address *Address `json:"address"`
+}
+
+// Address is a type used for testing source reading functionality.
+type Address struct {
+	Postcode string `json:"postcode"`
+}
+
+// Constraints is a valley constraints method used for testing source reading functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Address).Constraints(constraints.NotNil())
+	t.Field(s.Address.Postcode).Constraints(constraints.Required())
+}
@@ -0,0 +1,18 @@
+package td24
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	Age  int      `json:"age"`
+	Tags []string `json:"tags"`
+}
+
+// Constraints is a valley constraints method used for testing source reading functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Age).Constraints(constraints.WithMessage(constraints.Min(18), "you must be an adult"))
+	t.Field(s.Tags).Elements(constraints.WithCode(constraints.WithMessage(constraints.Required(), "tags must not be blank"), "blank_tag"))
+}
@@ -0,0 +1,19 @@
+package td25
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// adultMessage is a message that can't be used with WithMessage, as it isn't a string literal.
+const adultMessage = "you must be an adult"
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	Age int `json:"age"`
+}
+
+// Constraints is a valley constraints method used for testing source reading functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Age).Constraints(constraints.WithMessage(constraints.Min(18), adultMessage))
+}
@@ -0,0 +1,21 @@
+package td26
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	SomeText string `json:"some_text"`
+}
+
+// Create is a valley constraints method used for testing source reading functionality.
+func (s Subject) Create(t valley.Type) {
+	t.Field(s.SomeText).Constraints(constraints.Required())
+}
+
+// CreateConstraints is a valley constraints method used for testing source reading functionality.
+func (s Subject) CreateConstraints(t valley.Type) {
+	t.Field(s.SomeText).Constraints(constraints.MaxLength(8))
+}
@@ -0,0 +1,26 @@
+package td27
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing source reading functionality.
+type Subject struct {
+	SomeText string `json:"some_text"`
+}
+
+// Other is a type used for testing source reading functionality.
+type Other struct {
+	SomeText string `json:"some_text"`
+}
+
+// Text is a valley constraints function used for testing source reading functionality.
+func Text(s Subject, t valley.Type) {
+	t.Field(s.SomeText).Constraints(constraints.Required())
+}
+
+// TextConstraints is a valley constraints function used for testing source reading functionality.
+func TextConstraints(o Other, t valley.Type) {
+	t.Field(o.SomeText).Constraints(constraints.Required())
+}
@@ -0,0 +1,343 @@
+package config
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/seeruk/valley"
+)
+
+// Possible valueKind values.
+const (
+	valueKindOther valueKind = iota
+	valueKindBool
+	valueKindCollection
+	valueKindNumber
+	valueKindString
+)
+
+// valueKind is a broad classification of a value's type, used to decide which constraint a
+// validator rule maps on to, as many of them behave differently depending on the type (e.g. "min"
+// is a minimum length for strings, but a minimum value for numbers).
+type valueKind int
+
+// validatorOneOfPattern matches the values given to the "oneof" validator rule, which are separated
+// by spaces, but may also be wrapped in single quotes to include spaces.
+var validatorOneOfPattern = regexp.MustCompile(`'[^']*'|\S+`)
+
+// BuildFromValidatorTags builds Config for all structs declared in the source file of a given Source,
+// by reading rules from the struct tag with the given name (usually "validate") on each of their
+// fields, written using the syntax of github.com/go-playground/validator, e.g.:
+//
+//	Email string   `validate:"required,max=255,email"`
+//	Tags  []string `validate:"max=10,dive,required"`
+//
+// Rules are mapped on to the equivalent built-in constraints, taking the type of the field into
+// account. Rules after "dive" apply to each element of a slice or map (i.e. Elements), and rules
+// between "keys" and "endkeys" apply to each key of a map (i.e. Keys). Rules that can't be mapped
+// (e.g. cross-field rules, or alternatives using "|") are skipped, with a warning reported using
+// the Reporter given in the options. Unlike the validator, nested structs aren't validated unless
+// they're given the Valid constraint in a constraints method.
+//
+// Each struct with at least one rule produces configuration for a Validate method, which may be
+// merged with other Config using Merge.
+func BuildFromValidatorTags(src valley.Source, tagName string, opts ...Option) (valley.Config, error) {
+	options := options{
+		reporter: valley.DiscardReporter,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	config := valley.Config{
+		Types: make(map[string][]valley.TypeConfig),
+	}
+
+	for _, structName := range src.StructNames {
+		s, ok := src.Structs[structName]
+		if !ok || s.FileName != src.FileName || s.Node == nil {
+			// As with BuildFromTags, only structs declared in the source file are used.
+			continue
+		}
+
+		typeConfig, err := buildValidatorTypeConfig(src, options, s, tagName)
+		if err != nil {
+			return config, err
+		}
+
+		if len(typeConfig.Fields) > 0 {
+			config.Types[structName] = append(config.Types[structName], typeConfig)
+		}
+	}
+
+	return config, nil
+}
+
+// buildValidatorTypeConfig builds TypeConfig for a single struct by reading the validator struct
+// tag with the given name on each of it's fields.
+func buildValidatorTypeConfig(src valley.Source, options options, s valley.Struct, tagName string) (valley.TypeConfig, error) {
+	config := valley.TypeConfig{
+		Name:   ValidateMethodName("Constraints"),
+		Fields: make(map[string]valley.FieldConfig),
+	}
+
+	for _, field := range taggedFields(s.Node, "") {
+		rules, ok, err := readTagRules(field.node.Tag.Value, tagName)
+		if err != nil {
+			return config, errorOn(src, field.node.Tag.Pos(), "%v", err)
+		}
+
+		if !ok || len(rules) == 0 || rules[0] == "-" {
+			continue
+		}
+
+		for _, name := range field.names {
+			value, err := taggedFieldValue(src, s.Name, name, field.node.Tag.Pos())
+			if err != nil {
+				return config, err
+			}
+
+			fieldConfig, err := buildValidatorFieldConfig(src, options, value, rules, field.node.Tag.Pos())
+			if err != nil {
+				return config, err
+			}
+
+			if len(fieldConfig.Constraints) > 0 || len(fieldConfig.Elements) > 0 || len(fieldConfig.Keys) > 0 {
+				config.Fields[name] = fieldConfig
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// buildValidatorFieldConfig builds FieldConfig for a single field, from the given validator rules.
+func buildValidatorFieldConfig(src valley.Source, options options, field valley.Value, rules []string, pos token.Pos) (valley.FieldConfig, error) {
+	var config valley.FieldConfig
+
+	// Rules apply to the field itself until "dive" is seen, and then to it's elements, or keys.
+	target := &config.Constraints
+	value := field
+	diving := false
+
+	for i, rule := range rules {
+		name, arg := rule, ""
+		if j := strings.Index(rule, "="); j > -1 {
+			name, arg = rule[:j], rule[j+1:]
+		}
+
+		switch {
+		case name == "dive" && !diving:
+			target = &config.Elements
+			value = elementValue(field)
+			diving = true
+			continue
+		case name == "keys" && diving && i > 0 && rules[i-1] == "dive":
+			target = &config.Keys
+			value = keyValue(field)
+			continue
+		case name == "endkeys" && target == &config.Keys:
+			target = &config.Elements
+			value = elementValue(field)
+			continue
+		case name == "dive":
+			// Valley can only validate one level of elements, so nothing further can be used.
+			return config, warnOn(src, options, pos, "skipping unsupported nested %q in validator struct tag on field %q, and all following rules", name, field.Name)
+		}
+
+		constraint, args, ok := mapValidatorRule(name, arg, value)
+		if !ok || strings.Contains(rule, "|") {
+			err := warnOn(src, options, pos, "skipping unsupported rule %q in validator struct tag on field %q", rule, field.Name)
+			if err != nil {
+				return config, err
+			}
+
+			continue
+		}
+
+		constraintConfig, err := buildTagConstraint(src, pos, constraint, args)
+		if err != nil {
+			return config, errorOn(src, pos, "%v for rule %q in validator struct tag on field %q", err, name, field.Name)
+		}
+
+		*target = append(*target, constraintConfig)
+	}
+
+	return config, nil
+}
+
+// mapValidatorRule returns the name of the built-in constraint, and the arguments to pass to it,
+// that are equivalent to the validator rule with the given name and argument, for the given value.
+// If there is no equivalent constraint, false is returned.
+func mapValidatorRule(name, arg string, value valley.Value) (string, []string, bool) {
+	kind := valueKindOf(value)
+
+	// Arguments for strings are values, so must be quoted, but everything else takes Go expressions.
+	valueArg := arg
+	if kind == valueKindString {
+		valueArg = strconv.Quote(arg)
+	}
+
+	switch name {
+	case "required":
+		return "Required", nil, true
+	case "omitempty":
+		return "Optional", nil, true
+	case "len":
+		switch kind {
+		case valueKindString, valueKindCollection:
+			return "Length", []string{arg}, true
+		case valueKindNumber:
+			return "Equals", []string{arg}, true
+		}
+	case "min", "gte":
+		switch kind {
+		case valueKindString, valueKindCollection:
+			return "MinLength", []string{arg}, true
+		case valueKindNumber:
+			return "Min", []string{arg}, true
+		}
+	case "max", "lte":
+		switch kind {
+		case valueKindString, valueKindCollection:
+			return "MaxLength", []string{arg}, true
+		case valueKindNumber:
+			return "Max", []string{arg}, true
+		}
+	case "eq":
+		switch kind {
+		case valueKindCollection:
+			return "Length", []string{arg}, true
+		case valueKindString, valueKindNumber, valueKindBool:
+			return "Equals", []string{valueArg}, true
+		}
+	case "ne":
+		switch kind {
+		case valueKindString, valueKindNumber, valueKindBool:
+			return "NotEquals", []string{valueArg}, true
+		}
+	case "oneof":
+		if kind != valueKindString && kind != valueKindNumber {
+			break
+		}
+
+		var args []string
+		for _, value := range validatorOneOfPattern.FindAllString(arg, -1) {
+			if kind == valueKindString {
+				value = strconv.Quote(strings.Trim(value, "'"))
+			}
+
+			args = append(args, value)
+		}
+
+		return "OneOf", args, len(args) > 0
+	case "email":
+		if kind == valueKindString {
+			return "RegexpString", []string{strconv.Quote(valley.PatternEmail.String())}, true
+		}
+	case "uuid":
+		if kind == valueKindString {
+			return "RegexpString", []string{strconv.Quote(valley.PatternUUID.String())}, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// valueKindOf returns the kind of the given value's type, looking through pointers.
+func valueKindOf(value valley.Value) valueKind {
+	if value.ResolvedType != nil {
+		typ := value.ResolvedType.Underlying()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem().Underlying()
+		}
+
+		switch t := typ.(type) {
+		case *types.Basic:
+			switch info := t.Info(); {
+			case info&types.IsString != 0:
+				return valueKindString
+			case info&types.IsBoolean != 0:
+				return valueKindBool
+			case info&(types.IsInteger|types.IsFloat) != 0:
+				return valueKindNumber
+			}
+		case *types.Array, *types.Slice, *types.Map:
+			return valueKindCollection
+		}
+
+		return valueKindOther
+	}
+
+	typ := value.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	switch t := typ.(type) {
+	case *ast.ArrayType, *ast.MapType:
+		return valueKindCollection
+	case *ast.Ident:
+		switch {
+		case t.Name == "string":
+			return valueKindString
+		case t.Name == "bool":
+			return valueKindBool
+		case strings.HasPrefix(t.Name, "int"), strings.HasPrefix(t.Name, "uint"), strings.HasPrefix(t.Name, "float"):
+			return valueKindNumber
+		}
+	}
+
+	return valueKindOther
+}
+
+// elementValue returns a Value representing the elements of the given collection value.
+func elementValue(value valley.Value) valley.Value {
+	var element valley.Value
+
+	switch t := value.Type.(type) {
+	case *ast.ArrayType:
+		element.Type = t.Elt
+	case *ast.MapType:
+		element.Type = t.Value
+	}
+
+	if value.ResolvedType != nil {
+		switch t := value.ResolvedType.Underlying().(type) {
+		case *types.Array:
+			element.ResolvedType = t.Elem()
+		case *types.Slice:
+			element.ResolvedType = t.Elem()
+		case *types.Map:
+			element.ResolvedType = t.Elem()
+		}
+	}
+
+	element.Name = value.Name
+
+	return element
+}
+
+// keyValue returns a Value representing the keys of the given map value.
+func keyValue(value valley.Value) valley.Value {
+	var key valley.Value
+
+	if t, ok := value.Type.(*ast.MapType); ok {
+		key.Type = t.Key
+	}
+
+	if value.ResolvedType != nil {
+		if t, ok := value.ResolvedType.Underlying().(*types.Map); ok {
+			key.ResolvedType = t.Key()
+		}
+	}
+
+	key.Name = value.Name
+
+	return key
+}
@@ -0,0 +1,98 @@
+package valley
+
+// The types below are the details of the violations produced by the built-in constraints, which are
+// set as a ConstraintViolation's Details. Each one marshals to the same JSON as the map that was used
+// before, so their fields can be read without type-asserting nested values, e.g.:
+//
+//	if details, ok := violation.Details.(valley.MinDetails); ok {
+//	    fmt.Println(details.Minimum)
+//	}
+//
+// Violations produced by Nil, NotNil, Predicate, and Required have no details.
+
+// AnyNRequiredDetails are the details of violations of the AnyNRequired constraint.
+type AnyNRequiredDetails struct {
+	NumRequired int      `json:"num_required"`
+	Fields      []string `json:"fields"`
+}
+
+// DeepEqualsDetails are the details of violations of the DeepEquals constraint.
+type DeepEqualsDetails struct {
+	DeeplyEqualTo interface{} `json:"deeply_equal_to"`
+}
+
+// EqualsDetails are the details of violations of the Equals constraint.
+type EqualsDetails struct {
+	EqualTo interface{} `json:"equal_to"`
+}
+
+// ExactlyNRequiredDetails are the details of violations of the ExactlyNRequired constraint.
+type ExactlyNRequiredDetails struct {
+	NumRequired int      `json:"num_required"`
+	Fields      []string `json:"fields"`
+}
+
+// LengthDetails are the details of violations of the Length constraint.
+type LengthDetails struct {
+	Exactly int `json:"exactly"`
+}
+
+// MaxDetails are the details of violations of the Max constraint. Maximum is a float64, as bounds may
+// also be given for floating point values (e.g. by the validator "max" rule).
+type MaxDetails struct {
+	Maximum float64 `json:"maximum"`
+}
+
+// MaxLengthDetails are the details of violations of the MaxLength constraint.
+type MaxLengthDetails struct {
+	Maximum int `json:"maximum"`
+}
+
+// MinDetails are the details of violations of the Min constraint. Minimum is a float64, as bounds may
+// also be given for floating point values (e.g. by the validator "min" rule).
+type MinDetails struct {
+	Minimum float64 `json:"minimum"`
+}
+
+// MinLengthDetails are the details of violations of the MinLength constraint.
+type MinLengthDetails struct {
+	Minimum int `json:"minimum"`
+}
+
+// MutuallyExclusiveDetails are the details of violations of the MutuallyExclusive constraint. Fields
+// are the fields that were set.
+type MutuallyExclusiveDetails struct {
+	Fields []string `json:"fields"`
+}
+
+// MutuallyInclusiveDetails are the details of violations of the MutuallyInclusive constraint.
+type MutuallyInclusiveDetails struct {
+	Fields []string `json:"fields"`
+}
+
+// NotEqualsDetails are the details of violations of the NotEquals constraint.
+type NotEqualsDetails struct {
+	EqualTo interface{} `json:"equal_to"`
+}
+
+// OneOfDetails are the details of violations of the OneOf constraint.
+type OneOfDetails struct {
+	Allowed []interface{} `json:"allowed"`
+}
+
+// RegexpDetails are the details of violations of the Regexp and RegexpString constraints.
+type RegexpDetails struct {
+	Regexp string `json:"regexp"`
+}
+
+// TimeAfterDetails are the details of violations of the TimeAfter and TimeStringAfter constraints.
+// Time is formatted using time.RFC3339.
+type TimeAfterDetails struct {
+	Time string `json:"time"`
+}
+
+// TimeBeforeDetails are the details of violations of the TimeBefore and TimeStringBefore
+// constraints. Time is formatted using time.RFC3339.
+type TimeBeforeDetails struct {
+	Time string `json:"time"`
+}
@@ -0,0 +1,54 @@
+package valley
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetails(t *testing.T) {
+	tt := []struct {
+		name     string
+		details  interface{}
+		expected string
+	}{
+		{name: "AnyNRequired", details: AnyNRequiredDetails{NumRequired: 1, Fields: []string{"a", "b"}}, expected: `{"num_required":1,"fields":["a","b"]}`},
+		{name: "DeepEquals", details: DeepEqualsDetails{DeeplyEqualTo: []int{1}}, expected: `{"deeply_equal_to":[1]}`},
+		{name: "Equals", details: EqualsDetails{EqualTo: "foo"}, expected: `{"equal_to":"foo"}`},
+		{name: "ExactlyNRequired", details: ExactlyNRequiredDetails{NumRequired: 2, Fields: []string{"a"}}, expected: `{"num_required":2,"fields":["a"]}`},
+		{name: "Length", details: LengthDetails{Exactly: 3}, expected: `{"exactly":3}`},
+		{name: "Max", details: MaxDetails{Maximum: 5000000000}, expected: `{"maximum":5000000000}`},
+		{name: "MaxLength", details: MaxLengthDetails{Maximum: 255}, expected: `{"maximum":255}`},
+		{name: "Min", details: MinDetails{Minimum: 1.5}, expected: `{"minimum":1.5}`},
+		{name: "MinLength", details: MinLengthDetails{Minimum: 2}, expected: `{"minimum":2}`},
+		{name: "MutuallyExclusive", details: MutuallyExclusiveDetails{Fields: []string{"a", "b"}}, expected: `{"fields":["a","b"]}`},
+		{name: "MutuallyInclusive", details: MutuallyInclusiveDetails{Fields: []string{"a", "b"}}, expected: `{"fields":["a","b"]}`},
+		{name: "NotEquals", details: NotEqualsDetails{EqualTo: 1}, expected: `{"equal_to":1}`},
+		{name: "OneOf", details: OneOfDetails{Allowed: []interface{}{"a", 1}}, expected: `{"allowed":["a",1]}`},
+		{name: "Regexp", details: RegexpDetails{Regexp: "^a$"}, expected: `{"regexp":"^a$"}`},
+		{name: "TimeAfter", details: TimeAfterDetails{Time: "2020-01-01T00:00:00Z"}, expected: `{"time":"2020-01-01T00:00:00Z"}`},
+		{name: "TimeBefore", details: TimeBeforeDetails{Time: "2020-01-01T00:00:00Z"}, expected: `{"time":"2020-01-01T00:00:00Z"}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bs, err := json.Marshal(ConstraintViolation{Details: tc.details})
+			require.NoError(t, err)
+
+			var actual struct {
+				Details json.RawMessage `json:"details"`
+			}
+
+			require.NoError(t, json.Unmarshal(bs, &actual))
+			assert.JSONEq(t, tc.expected, string(actual.Details))
+		})
+	}
+
+	t.Run("should omit empty details", func(t *testing.T) {
+		bs, err := json.Marshal(ConstraintViolation{PathKind: "field"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"path_kind":"field","message":""}`, string(bs))
+	})
+}
@@ -0,0 +1,87 @@
+package valley
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// All possible Severity values.
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Severity enumerates the possible severities of a Diagnostic.
+type Severity string
+
+// Diagnostic is a problem found in some Go source whilst generating validation code for it, along
+// with where it was found. Diagnostics with an error severity are returned as errors.
+type Diagnostic struct {
+	File       string   `json:"file"`
+	Line       int      `json:"line"`
+	Column     int      `json:"column"`
+	Severity   Severity `json:"severity"`
+	Constraint string   `json:"constraint,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	Field      string   `json:"field,omitempty"`
+	Message    string   `json:"message"`
+}
+
+// Error returns the diagnostic as a human-readable message, including it's position, if it has one.
+func (d Diagnostic) Error() string {
+	switch {
+	case d.File == "":
+		return d.Message
+	case d.Line == 0:
+		return fmt.Sprintf("%s in '%s'", d.Message, d.File)
+	default:
+		return fmt.Sprintf("%s on line %d, col %d in '%s'", d.Message, d.Line, d.Column, d.File)
+	}
+}
+
+// Reporter is used to report Diagnostics, e.g. to a user, as they're found.
+type Reporter interface {
+	Report(diagnostic Diagnostic)
+}
+
+// TextReporter is a Reporter that writes Diagnostics to a writer as human-readable text, one per
+// line.
+type TextReporter struct {
+	w io.Writer
+}
+
+// NewTextReporter returns a new TextReporter instance.
+func NewTextReporter(w io.Writer) *TextReporter {
+	return &TextReporter{w: w}
+}
+
+// Report writes the given diagnostic as text.
+func (r *TextReporter) Report(diagnostic Diagnostic) {
+	fmt.Fprintf(r.w, "valley: %s: %v\n", diagnostic.Severity, diagnostic)
+}
+
+// JSONReporter is a Reporter that writes Diagnostics to a writer as JSON, one object per line.
+type JSONReporter struct {
+	encoder *json.Encoder
+}
+
+// NewJSONReporter returns a new JSONReporter instance.
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{encoder: json.NewEncoder(w)}
+}
+
+// Report writes the given diagnostic as JSON.
+func (r *JSONReporter) Report(diagnostic Diagnostic) {
+	// Diagnostics are always encodable.
+	_ = r.encoder.Encode(diagnostic)
+}
+
+// discardReporter is a Reporter that does nothing with the Diagnostics it's given.
+type discardReporter struct{}
+
+// Report does nothing.
+func (discardReporter) Report(Diagnostic) {}
+
+// DiscardReporter is a Reporter that ignores all Diagnostics, useful as a default.
+var DiscardReporter Reporter = discardReporter{}
@@ -0,0 +1,60 @@
+package valley
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnostic_Error(t *testing.T) {
+	t.Run("should include the message and position", func(t *testing.T) {
+		diagnostic := Diagnostic{
+			File:    "example.go",
+			Line:    12,
+			Column:  3,
+			Message: "something went wrong",
+		}
+
+		assert.Equal(t, "something went wrong on line 12, col 3 in 'example.go'", diagnostic.Error())
+	})
+}
+
+func TestTextReporter_Report(t *testing.T) {
+	t.Run("should write the diagnostic as a line of text", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		NewTextReporter(buf).Report(Diagnostic{
+			File:     "example.go",
+			Line:     12,
+			Column:   3,
+			Severity: SeverityWarning,
+			Message:  "something went wrong",
+		})
+
+		assert.Equal(t, "valley: warning: something went wrong on line 12, col 3 in 'example.go'\n", buf.String())
+	})
+}
+
+func TestJSONReporter_Report(t *testing.T) {
+	t.Run("should write the diagnostic as a line of JSON", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		NewJSONReporter(buf).Report(Diagnostic{
+			File:       "example.go",
+			Line:       12,
+			Column:     3,
+			Severity:   SeverityError,
+			Constraint: "github.com/seeruk/valley/validation/constraints.Required",
+			Type:       "Example",
+			Field:      "Text",
+			Message:    "something went wrong",
+		})
+
+		expected := `{"file":"example.go","line":12,"column":3,"severity":"error",` +
+			`"constraint":"github.com/seeruk/valley/validation/constraints.Required",` +
+			`"type":"Example","field":"Text","message":"something went wrong"}` + "\n"
+
+		assert.Equal(t, expected, buf.String())
+	})
+}
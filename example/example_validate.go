@@ -39,10 +39,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "struct",
+				Code:     "mutually_inclusive",
 				Message:  "fields are mutually inclusive",
-				Details: map[string]interface{}{
-					"fields": []string{"text", "texts"},
-				},
+				Details:  valley.MutuallyInclusiveDetails{Fields: []string{"text", "texts"}},
 			})
 
 		}
@@ -69,10 +68,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "struct",
+				Code:     "mutually_inclusive",
 				Message:  "fields are mutually inclusive",
-				Details: map[string]interface{}{
-					"fields": []string{"int", "int2", "ints"},
-				},
+				Details:  valley.MutuallyInclusiveDetails{Fields: []string{"int", "int2", "ints"}},
 			})
 
 		}
@@ -103,40 +101,43 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "struct",
+				Code:     "exactly_n_required",
 				Message:  "exact number of required fields not met",
-				Details: map[string]interface{}{
-					"num_required": 3,
-					"fields":       []string{"int", "int2", "ints", "text"},
-				},
+				Details:  valley.ExactlyNRequiredDetails{NumRequired: int(3), Fields: []string{"int", "int2", "ints", "text"}},
 			})
 
 		}
 	}
 
-	if e.Adults < 1 {
-		size := path.Write("adults")
-		violations = append(violations, valley.ConstraintViolation{
-			Path:     path.String(),
-			PathKind: "field",
-			Message:  "minimum value not met",
-			Details: map[string]interface{}{
-				"minimum": 1,
-			},
-		})
-		path.TruncateRight(size)
+	if !(e.Adults == 0) {
+
+		if e.Adults < 1 {
+			size := path.Write("adults")
+			violations = append(violations, valley.ConstraintViolation{
+				Path:     path.String(),
+				PathKind: "field",
+				Code:     "min",
+				Message:  "minimum value not met",
+				Details:  valley.MinDetails{Minimum: float64(1)},
+			})
+			path.TruncateRight(size)
+		}
+
 	}
+	if !(e.Adults == 0) {
+
+		if e.Adults > 9 {
+			size := path.Write("adults")
+			violations = append(violations, valley.ConstraintViolation{
+				Path:     path.String(),
+				PathKind: "field",
+				Code:     "max",
+				Message:  "maximum value exceeded",
+				Details:  valley.MaxDetails{Maximum: float64(9)},
+			})
+			path.TruncateRight(size)
+		}
 
-	if e.Adults > 9 {
-		size := path.Write("adults")
-		violations = append(violations, valley.ConstraintViolation{
-			Path:     path.String(),
-			PathKind: "field",
-			Message:  "maximum value exceeded",
-			Details: map[string]interface{}{
-				"maximum": 9,
-			},
-		})
-		path.TruncateRight(size)
 	}
 
 	if e.Bool == false {
@@ -144,10 +145,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "not_equals",
 			Message:  "values must not be equal",
-			Details: map[string]interface{}{
-				"equal_to": false,
-			},
+			Details:  valley.NotEqualsDetails{EqualTo: false},
 		})
 		path.TruncateRight(size)
 	}
@@ -157,38 +157,43 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "deep_equals",
 			Message:  "values must be deeply equal",
-			Details: map[string]interface{}{
-				"deeply_equal_to": true,
-			},
+			Details:  valley.DeepEqualsDetails{DeeplyEqualTo: true},
 		})
 		path.TruncateRight(size)
 	}
 
-	if len(e.Chan) > 12 {
-		size := path.Write("chan")
-		violations = append(violations, valley.ConstraintViolation{
-			Path:     path.String(),
-			PathKind: "field",
-			Message:  "maximum length exceeded",
-			Details: map[string]interface{}{
-				"maximum": 12,
-			},
-		})
-		path.TruncateRight(size)
+	if !(e.Chan == nil) {
+
+		if len(e.Chan) > 12 {
+			size := path.Write("chan")
+			violations = append(violations, valley.ConstraintViolation{
+				Path:     path.String(),
+				PathKind: "field",
+				Code:     "max_length",
+				Message:  "maximum length exceeded",
+				Details:  valley.MaxLengthDetails{Maximum: int(12)},
+			})
+			path.TruncateRight(size)
+		}
+
 	}
 
-	if e.Children < 0 {
-		size := path.Write("children")
-		violations = append(violations, valley.ConstraintViolation{
-			Path:     path.String(),
-			PathKind: "field",
-			Message:  "minimum value not met",
-			Details: map[string]interface{}{
-				"minimum": 0,
-			},
-		})
-		path.TruncateRight(size)
+	if !(e.Children == 0) {
+
+		if e.Children < 0 {
+			size := path.Write("children")
+			violations = append(violations, valley.ConstraintViolation{
+				Path:     path.String(),
+				PathKind: "field",
+				Code:     "min",
+				Message:  "minimum value not met",
+				Details:  valley.MinDetails{Minimum: float64(0)},
+			})
+			path.TruncateRight(size)
+		}
+
 	}
 
 	if e.Children != e.Adults+2 {
@@ -196,25 +201,27 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "equals",
 			Message:  "values must be equal",
-			Details: map[string]interface{}{
-				"equal_to": e.Adults + 2,
-			},
+			Details:  valley.EqualsDetails{EqualTo: e.Adults + 2},
 		})
 		path.TruncateRight(size)
 	}
 
-	if e.Children > int(math.Max(float64(8-(e.Adults-1)), 0)) {
-		size := path.Write("children")
-		violations = append(violations, valley.ConstraintViolation{
-			Path:     path.String(),
-			PathKind: "field",
-			Message:  "maximum value exceeded",
-			Details: map[string]interface{}{
-				"maximum": int(math.Max(float64(8-(e.Adults-1)), 0)),
-			},
-		})
-		path.TruncateRight(size)
+	if !(e.Children == 0) {
+
+		if e.Children > int(math.Max(float64(8-(e.Adults-1)), 0)) {
+			size := path.Write("children")
+			violations = append(violations, valley.ConstraintViolation{
+				Path:     path.String(),
+				PathKind: "field",
+				Code:     "max",
+				Message:  "maximum value exceeded",
+				Details:  valley.MaxDetails{Maximum: float64(int(math.Max(float64(8-(e.Adults-1)), 0)))},
+			})
+			path.TruncateRight(size)
+		}
+
 	}
 
 	if e.Float != math.Pi {
@@ -222,10 +229,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "equals",
 			Message:  "values must be equal",
-			Details: map[string]interface{}{
-				"equal_to": math.Pi,
-			},
+			Details:  valley.EqualsDetails{EqualTo: math.Pi},
 		})
 		path.TruncateRight(size)
 	}
@@ -235,6 +241,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "required",
 			Message:  "a value is required",
 		})
 		path.TruncateRight(size)
@@ -245,6 +252,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "required",
 			Message:  "a value is required",
 		})
 		path.TruncateRight(size)
@@ -255,6 +263,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "not_nil",
 			Message:  "value must not be nil",
 		})
 		path.TruncateRight(size)
@@ -265,10 +274,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "min",
 			Message:  "minimum value not met",
-			Details: map[string]interface{}{
-				"minimum": 0,
-			},
+			Details:  valley.MinDetails{Minimum: float64(0)},
 		})
 		path.TruncateRight(size)
 	}
@@ -278,6 +286,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "required",
 			Message:  "a value is required",
 		})
 		path.TruncateRight(size)
@@ -288,10 +297,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "max_length",
 			Message:  "maximum length exceeded",
-			Details: map[string]interface{}{
-				"maximum": 3,
-			},
+			Details:  valley.MaxLengthDetails{Maximum: int(3)},
 		})
 		path.TruncateRight(size)
 	}
@@ -303,6 +311,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "element",
+				Code:     "required",
 				Message:  "a value is required",
 			})
 			path.TruncateRight(size)
@@ -313,10 +322,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "element",
+				Code:     "min",
 				Message:  "minimum value not met",
-				Details: map[string]interface{}{
-					"minimum": 0,
-				},
+				Details:  valley.MinDetails{Minimum: float64(0)},
 			})
 			path.TruncateRight(size)
 		}
@@ -328,6 +336,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "required",
 			Message:  "a value is required",
 		})
 		path.TruncateRight(size)
@@ -340,9 +349,11 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 	}
 
 	for key := range e.NestedMap {
-		size := path.Write("nested_map.[" + fmt.Sprintf("%v", key) + "]")
-		violations = append(violations, key.Validate(path)...)
-		path.TruncateRight(size)
+		{
+			size := path.Write("nested_map.[" + fmt.Sprintf("%v", key) + "]")
+			violations = append(violations, key.Validate(path)...)
+			path.TruncateRight(size)
+		}
 
 	}
 
@@ -360,6 +371,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "required",
 			Message:  "a value is required",
 		})
 		path.TruncateRight(size)
@@ -370,10 +382,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "regexp",
 			Message:  "value must match regular expression",
-			Details: map[string]interface{}{
-				"regexp": patternGreeting.String(),
-			},
+			Details:  valley.RegexpDetails{Regexp: patternGreeting.String()},
 		})
 		path.TruncateRight(size)
 	}
@@ -383,10 +394,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "max_length",
 			Message:  "maximum length exceeded",
-			Details: map[string]interface{}{
-				"maximum": 12,
-			},
+			Details:  valley.MaxLengthDetails{Maximum: int(12)},
 		})
 		path.TruncateRight(size)
 	}
@@ -396,10 +406,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "length",
 			Message:  "exact length not met",
-			Details: map[string]interface{}{
-				"exactly": 5,
-			},
+			Details:  valley.LengthDetails{Exactly: int(5)},
 		})
 		path.TruncateRight(size)
 	}
@@ -409,10 +418,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "one_of",
 			Message:  "value must be one of the allowed values",
-			Details: map[string]interface{}{
-				"allowed": []interface{}{"Hello, World!", "Hello, SeerUK!", "Hello, GitHub!"},
-			},
+			Details:  valley.OneOfDetails{Allowed: []interface{}{"Hello, World!", "Hello, SeerUK!", "Hello, GitHub!"}},
 		})
 		path.TruncateRight(size)
 	}
@@ -422,6 +430,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "predicate",
 			Message:  "\"value must be a valid custom ID\"",
 		})
 		path.TruncateRight(size)
@@ -434,6 +443,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "field",
+				Code:     "required",
 				Message:  "a value is required",
 			})
 			path.TruncateRight(size)
@@ -444,10 +454,9 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "field",
+				Code:     "min_length",
 				Message:  "minimum length not met",
-				Details: map[string]interface{}{
-					"minimum": 64,
-				},
+				Details:  valley.MinLengthDetails{Minimum: int(64)},
 			})
 			path.TruncateRight(size)
 		}
@@ -459,6 +468,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "required",
 			Message:  "a value is required",
 		})
 		path.TruncateRight(size)
@@ -471,6 +481,7 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
 				PathKind: "element",
+				Code:     "required",
 				Message:  "a value is required",
 			})
 			path.TruncateRight(size)
@@ -479,64 +490,71 @@ func (e Example) Validate(path *valley.Path) []valley.ConstraintViolation {
 	}
 
 	for key := range e.TextMap {
+		if !(len(key) == 0) {
+
+			if len(key) < 10 {
+				size := path.Write("text_map.[" + fmt.Sprintf("%v", key) + "]")
+				violations = append(violations, valley.ConstraintViolation{
+					Path:     path.String(),
+					PathKind: "key",
+					Code:     "min_length",
+					Message:  "minimum length not met",
+					Details:  valley.MinLengthDetails{Minimum: int(10)},
+				})
+				path.TruncateRight(size)
+			}
+
+		}
+	}
+
+	if !(e.Time.IsZero()) {
 
-		if len(key) < 10 {
-			size := path.Write("text_map.[" + fmt.Sprintf("%v", key) + "]")
+		if !e.Time.Before(timeYosemite) {
+			size := path.Write("time")
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
-				PathKind: "key",
-				Message:  "minimum length not met",
-				Details: map[string]interface{}{
-					"minimum": 10,
-				},
+				PathKind: "field",
+				Code:     "time_before",
+				Message:  "value must be before time",
+				Details:  valley.TimeBeforeDetails{Time: timeYosemite.Format(time.RFC3339)},
 			})
 			path.TruncateRight(size)
 		}
 
 	}
 
-	if !e.Time.Before(timeYosemite) {
-		size := path.Write("time")
-		violations = append(violations, valley.ConstraintViolation{
-			Path:     path.String(),
-			PathKind: "field",
-			Message:  "value must be before time",
-			Details: map[string]interface{}{
-				"time": timeYosemite.Format(time.RFC3339),
-			},
-		})
-		path.TruncateRight(size)
-	}
-
-	if len(e.Times) < 1 {
-		size := path.Write("times")
-		violations = append(violations, valley.ConstraintViolation{
-			Path:     path.String(),
-			PathKind: "field",
-			Message:  "minimum length not met",
-			Details: map[string]interface{}{
-				"minimum": 1,
-			},
-		})
-		path.TruncateRight(size)
-	}
-
-	for i, element := range e.Times {
+	if !(len(e.Times) == 0) {
 
-		if !element.Before(timeYosemite) {
-			size := path.Write("times.[" + strconv.Itoa(i) + "]")
+		if len(e.Times) < 1 {
+			size := path.Write("times")
 			violations = append(violations, valley.ConstraintViolation{
 				Path:     path.String(),
-				PathKind: "element",
-				Message:  "value must be before time",
-				Details: map[string]interface{}{
-					"time": timeYosemite.Format(time.RFC3339),
-				},
+				PathKind: "field",
+				Code:     "min_length",
+				Message:  "minimum length not met",
+				Details:  valley.MinLengthDetails{Minimum: int(1)},
 			})
 			path.TruncateRight(size)
 		}
 
 	}
+	for i, element := range e.Times {
+		if !(element.IsZero()) {
+
+			if !element.Before(timeYosemite) {
+				size := path.Write("times.[" + strconv.Itoa(i) + "]")
+				violations = append(violations, valley.ConstraintViolation{
+					Path:     path.String(),
+					PathKind: "element",
+					Code:     "time_before",
+					Message:  "value must be before time",
+					Details:  valley.TimeBeforeDetails{Time: timeYosemite.Format(time.RFC3339)},
+				})
+				path.TruncateRight(size)
+			}
+
+		}
+	}
 
 	path.TruncateRight(1)
 
@@ -555,6 +573,7 @@ func (n NestedExample) Validate(path *valley.Path) []valley.ConstraintViolation
 		violations = append(violations, valley.ConstraintViolation{
 			Path:     path.String(),
 			PathKind: "field",
+			Code:     "required",
 			Message:  "a value is required",
 		})
 		path.TruncateRight(size)
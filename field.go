@@ -0,0 +1,255 @@
+package valley
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldPath returns the Value of each field along the given path (e.g. "Address.Postcode") through
+// the struct with the given name, in order, so that the last Value is the field the path refers
+// to. Each field along the path, other than the last, must be a struct, or a pointer to one. This
+// may be a struct declared in the same package, an anonymous inline struct, or a struct from
+// another package.
+//
+// Fields promoted from embedded structs may be used as if they were declared on the struct that
+// embeds them, in which case the embedded fields they're promoted through are also returned.
+func (s Source) FieldPath(structName, fieldPath string) ([]Value, error) {
+	st, ok := s.Structs[structName]
+	if !ok {
+		return nil, fmt.Errorf("struct %q does not exist in Go source", structName)
+	}
+
+	names := strings.Split(fieldPath, ".")
+	values := make([]Value, 0, len(names))
+	fields := st.Fields
+
+	for i, name := range names {
+		path, err := s.promotedFieldPath(fields, name)
+		if err != nil {
+			return nil, fmt.Errorf("field %q %v", strings.Join(names[:i+1], "."), err)
+		}
+
+		values = append(values, path...)
+
+		if i < len(names)-1 {
+			fields, ok = s.structFields(path[len(path)-1])
+			if !ok {
+				return nil, fmt.Errorf("field %q is not a struct", strings.Join(names[:i+1], "."))
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// promotedFieldPath finds the field with the given name in the given fields, or if it's not there,
+// in the fields of any embedded structs (following Go's rules for promoted fields, i.e. the least
+// deeply embedded field is used). The embedded fields that the field is found through are returned
+// before it.
+func (s Source) promotedFieldPath(fields Fields, name string) ([]Value, error) {
+	if value, ok := fields[name]; ok {
+		return []Value{value}, nil
+	}
+
+	level := [][]Value{{}}
+	seen := make(map[string]bool)
+
+	for len(level) > 0 {
+		var next, found [][]Value
+		var levelSeen []string
+
+		for _, path := range level {
+			levelFields := fields
+			if len(path) > 0 {
+				levelFields, _ = s.structFields(path[len(path)-1])
+			}
+
+			for _, fieldName := range sortedFieldNames(levelFields) {
+				value := levelFields[fieldName]
+				if !value.Embedded {
+					continue
+				}
+
+				embeddedFields, ok := s.structFields(value)
+				if !ok {
+					continue
+				}
+
+				// A type embedded at a shallower depth (e.g. through a pointer to itself) can't make
+				// any more fields available, and could otherwise be searched forever.
+				typeString := types.ExprString(value.Type)
+				if seen[typeString] {
+					continue
+				}
+
+				levelSeen = append(levelSeen, typeString)
+
+				embeddedPath := append(append([]Value{}, path...), value)
+				if field, ok := embeddedFields[name]; ok {
+					found = append(found, append(embeddedPath, field))
+				}
+
+				next = append(next, embeddedPath)
+			}
+		}
+
+		switch {
+		case len(found) == 1:
+			return found[0], nil
+		case len(found) > 1:
+			return nil, errors.New("is ambiguous, as it's promoted from more than one embedded struct")
+		}
+
+		for _, typeString := range levelSeen {
+			seen[typeString] = true
+		}
+
+		level = next
+	}
+
+	return nil, errors.New("does not exist in Go source")
+}
+
+// EmbeddedFieldName returns the identifier that the embedded field with the given type is named
+// after (e.g. "T" for "*pkg.T"). If the given type can't be embedded, false is returned.
+func EmbeddedFieldName(typ ast.Expr) (*ast.Ident, bool) {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t, true
+	case *ast.SelectorExpr:
+		return t.Sel, true
+	}
+
+	return nil, false
+}
+
+// IsPointer returns true if this Value is a pointer.
+func (v Value) IsPointer() bool {
+	if _, ok := v.Type.(*ast.StarExpr); ok {
+		return true
+	}
+
+	if v.ResolvedType != nil {
+		_, ok := v.ResolvedType.Underlying().(*types.Pointer)
+		return ok
+	}
+
+	return false
+}
+
+// structFields returns the fields of the struct that the given Value is (or points to). Fields of
+// structs declared in the same package, and of anonymous inline structs, come from their AST, and
+// fields of structs from other packages are built from their type information.
+func (s Source) structFields(value Value) (Fields, bool) {
+	expr := value.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	var structType *types.Struct
+	if value.ResolvedType != nil {
+		typ := value.ResolvedType.Underlying()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem().Underlying()
+		}
+
+		structType, _ = typ.(*types.Struct)
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if st, ok := s.Structs[t.Name]; ok {
+			return st.Fields, true
+		}
+	case *ast.StructType:
+		return inlineStructFields(t, structType), true
+	}
+
+	if structType == nil {
+		return nil, false
+	}
+
+	fields := make(Fields, structType.NumFields())
+
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+
+		// The type's AST is only inspected, never rendered, so the imports it needs aren't used.
+		typeExpr, _ := s.TypeExpr(field.Type())
+
+		fields[field.Name()] = Value{
+			Name:         field.Name(),
+			Type:         typeExpr,
+			ResolvedType: field.Type(),
+			Tag:          structType.Tag(i),
+			Embedded:     field.Embedded(),
+		}
+	}
+
+	return fields, true
+}
+
+// inlineStructFields returns the fields of the given anonymous inline struct, using the given type
+// information for it to resolve their types, if it's available.
+func inlineStructFields(node *ast.StructType, structType *types.Struct) Fields {
+	resolvedTypes := make(map[string]types.Type)
+	if structType != nil {
+		for i := 0; i < structType.NumFields(); i++ {
+			resolvedTypes[structType.Field(i).Name()] = structType.Field(i).Type()
+		}
+	}
+
+	fields := make(Fields)
+
+	for _, field := range node.Fields.List {
+		var tag string
+		if field.Tag != nil {
+			tag, _ = strconv.Unquote(field.Tag.Value)
+		}
+
+		names := field.Names
+		embedded := len(names) == 0
+
+		if embedded {
+			name, ok := EmbeddedFieldName(field.Type)
+			if !ok {
+				continue
+			}
+
+			names = []*ast.Ident{name}
+		}
+
+		for _, name := range names {
+			fields[name.Name] = Value{
+				Name:         name.Name,
+				Type:         field.Type,
+				ResolvedType: resolvedTypes[name.Name],
+				Tag:          tag,
+				Embedded:     embedded,
+			}
+		}
+	}
+
+	return fields
+}
+
+// sortedFieldNames returns the names of the given fields, in order.
+func sortedFieldNames(fields Fields) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
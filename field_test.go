@@ -0,0 +1,120 @@
+package valley
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource_FieldPath(t *testing.T) {
+	src := Source{
+		Structs: Structs{
+			"Subject": Struct{
+				Name: "Subject",
+				Fields: Fields{
+					"Name":    Value{Name: "Name", Type: mustParseExpr(t, "string")},
+					"Address": Value{Name: "Address", Type: mustParseExpr(t, "*Address")},
+					"Meta":    Value{Name: "Meta", Type: mustParseExpr(t, "struct { Owner string `json:\"owner\"` }")},
+					"Audit":   Value{Name: "Audit", Type: mustParseExpr(t, "*Audit"), Embedded: true},
+					"Other":   Value{Name: "Other", Type: mustParseExpr(t, "Other"), Embedded: true},
+				},
+			},
+			"Audit": Struct{
+				Name: "Audit",
+				Fields: Fields{
+					"Timestamps": Value{Name: "Timestamps", Type: mustParseExpr(t, "Timestamps"), Embedded: true},
+					"Owner":      Value{Name: "Owner", Type: mustParseExpr(t, "string")},
+				},
+			},
+			"Other": Struct{
+				Name: "Other",
+				Fields: Fields{
+					"Owner": Value{Name: "Owner", Type: mustParseExpr(t, "string")},
+				},
+			},
+			"Timestamps": Struct{
+				Name: "Timestamps",
+				Fields: Fields{
+					"CreatedAt": Value{Name: "CreatedAt", Type: mustParseExpr(t, "string")},
+				},
+			},
+			"Address": Struct{
+				Name: "Address",
+				Fields: Fields{
+					"Postcode": Value{Name: "Postcode", Type: mustParseExpr(t, "string")},
+				},
+			},
+		},
+	}
+
+	t.Run("should return the value of a field on the struct", func(t *testing.T) {
+		values, err := src.FieldPath("Subject", "Name")
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		assert.Equal(t, "Name", values[0].Name)
+	})
+
+	t.Run("should return the value of each field through nested structs", func(t *testing.T) {
+		values, err := src.FieldPath("Subject", "Address.Postcode")
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		assert.Equal(t, "Address", values[0].Name)
+		assert.True(t, values[0].IsPointer())
+		assert.Equal(t, "Postcode", values[1].Name)
+	})
+
+	t.Run("should return the value of fields on anonymous inline structs", func(t *testing.T) {
+		values, err := src.FieldPath("Subject", "Meta.Owner")
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		assert.Equal(t, "Owner", values[1].Name)
+		assert.Equal(t, `json:"owner"`, values[1].Tag)
+	})
+
+	t.Run("should return the value of fields promoted from embedded structs", func(t *testing.T) {
+		values, err := src.FieldPath("Subject", "CreatedAt")
+		require.NoError(t, err)
+		require.Len(t, values, 3)
+		assert.Equal(t, "Audit", values[0].Name)
+		assert.True(t, values[0].Embedded)
+		assert.Equal(t, "Timestamps", values[1].Name)
+		assert.Equal(t, "CreatedAt", values[2].Name)
+	})
+
+	t.Run("should return the value of embedded structs by their type name", func(t *testing.T) {
+		values, err := src.FieldPath("Subject", "Audit.Timestamps.CreatedAt")
+		require.NoError(t, err)
+		require.Len(t, values, 3)
+		assert.Equal(t, "CreatedAt", values[2].Name)
+	})
+
+	t.Run("should error if a promoted field is ambiguous", func(t *testing.T) {
+		_, err := src.FieldPath("Subject", "Owner")
+		assert.EqualError(t, err, `field "Owner" is ambiguous, as it's promoted from more than one embedded struct`)
+	})
+
+	t.Run("should error if a field doesn't exist", func(t *testing.T) {
+		_, err := src.FieldPath("Subject", "Address.Nonexistent")
+		assert.EqualError(t, err, `field "Address.Nonexistent" does not exist in Go source`)
+	})
+
+	t.Run("should error if a field along the path isn't a struct", func(t *testing.T) {
+		_, err := src.FieldPath("Subject", "Name.Length")
+		assert.EqualError(t, err, `field "Name" is not a struct`)
+	})
+
+	t.Run("should error if the struct doesn't exist", func(t *testing.T) {
+		_, err := src.FieldPath("Nonexistent", "Name")
+		assert.Error(t, err)
+	})
+}
+
+func mustParseExpr(t *testing.T, expr string) ast.Expr {
+	parsed, err := parser.ParseExpr(expr)
+	require.NoError(t, err)
+
+	return parsed
+}
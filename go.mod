@@ -1,6 +1,6 @@
 module github.com/seeruk/valley
 
-go 1.13
+go 1.18
 
 require (
 	github.com/davecgh/go-spew v1.1.1
@@ -8,3 +8,9 @@ require (
 	github.com/seeruk/go-console v0.1.0-alpha.5
 	github.com/stretchr/testify v1.4.0
 )
+
+require (
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/seeruk/go-wordwrap v0.0.0-20191208221741-14ec4aac9550 // indirect
+	gopkg.in/yaml.v2 v2.2.7 // indirect
+)
@@ -0,0 +1,243 @@
+package source
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/seeruk/valley"
+)
+
+// Find returns the paths of Go source files matching the given pattern that Valley should generate
+// code for. The pattern may be the path to a single file, which is always returned; the path to a
+// directory, in which case each file in it that declares constraints is returned; or the path to a
+// directory followed by "/..." (e.g. "./..."), which also searches all of it's subdirectories in
+// the same way as the go tool does (i.e. skipping "testdata", "vendor", and hidden directories).
+//
+// Files that are configured in other ways may also be found, using the given options.
+func Find(pattern string, opts ...FindOption) ([]string, error) {
+	var options findOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if pattern == "..." || strings.HasSuffix(pattern, "/...") {
+		return findRecursive(filepath.Clean(strings.TrimSuffix(pattern, "...")), options)
+	}
+
+	info, err := os.Stat(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source: %v", err)
+	}
+
+	if !info.IsDir() {
+		return []string{pattern}, nil
+	}
+
+	return findInDir(pattern, options)
+}
+
+// FindOption is a function that configures which files Find returns.
+type FindOption func(o *findOptions)
+
+// findOptions holds the settings that affect which files Find returns.
+type findOptions struct {
+	tagNames  []string
+	typeNames []string
+}
+
+// WithTags returns a FindOption that makes Find also return files that declare structs with fields
+// that have any of the given struct tags, as they may declare constraints in them instead.
+func WithTags(tagNames ...string) FindOption {
+	return func(o *findOptions) {
+		o.tagNames = append(o.tagNames, tagNames...)
+	}
+}
+
+// WithTypes returns a FindOption that makes Find also return files that declare any of the types
+// with the given names, e.g. because they have constraints configured elsewhere.
+func WithTypes(typeNames ...string) FindOption {
+	return func(o *findOptions) {
+		o.typeNames = append(o.typeNames, typeNames...)
+	}
+}
+
+// findRecursive returns the paths of Go source files that declare constraints in the given
+// directory, and all of it's subdirectories.
+func findRecursive(root string, options findOptions) ([]string, error) {
+	var srcPaths []string
+
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		name := info.Name()
+		if p != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+
+		dirPaths, err := findInDir(p, options)
+		if err != nil {
+			return err
+		}
+
+		srcPaths = append(srcPaths, dirPaths...)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source: %v", err)
+	}
+
+	return srcPaths, nil
+}
+
+// findInDir returns the paths of Go source files that declare constraints in the given directory.
+// Test files, files excluded by build constraints, and files generated by Valley are skipped.
+func findInDir(dir string, options findOptions) ([]string, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read package in %q: %v", dir, err)
+	}
+
+	var srcPaths []string
+
+	fileSet := token.NewFileSet()
+
+	for _, name := range pkg.GoFiles {
+		srcPath := filepath.Join(dir, name)
+
+		file, err := parser.ParseFile(fileSet, srcPath, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse source: %v", err)
+		}
+
+		if isGenerated(file) {
+			continue
+		}
+
+		if declaresConstraints(file) || declaresTags(file, options.tagNames) || declaresTypes(file, options.typeNames) {
+			srcPaths = append(srcPaths, srcPath)
+		}
+	}
+
+	return srcPaths, nil
+}
+
+// declaresConstraints returns true if the given file appears to declare any constraints methods or
+// functions, i.e. functions that return nothing, and accept a valley.Type as their last argument.
+// This is only a quick check, the config package decides exactly what will be used.
+func declaresConstraints(file *ast.File) bool {
+	var alias string
+
+	for _, imp := range file.Imports {
+		impPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || impPath != valley.ImportPath {
+			continue
+		}
+
+		alias = path.Base(impPath)
+		if imp.Name != nil {
+			alias = imp.Name.Name
+		}
+	}
+
+	if alias == "" {
+		return false
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Type.Results != nil || funcDecl.Type.Params == nil {
+			continue
+		}
+
+		params := funcDecl.Type.Params.List
+		if len(params) == 0 {
+			continue
+		}
+
+		selector, ok := params[len(params)-1].Type.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "Type" {
+			continue
+		}
+
+		if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == alias {
+			return true
+		}
+	}
+
+	return false
+}
+
+// declaresTags returns true if the given file declares any struct types with fields that have any
+// of the given struct tags. Like declaresConstraints, this is only a quick check.
+func declaresTags(file *ast.File, tagNames []string) bool {
+	if len(tagNames) == 0 {
+		return false
+	}
+
+	var found bool
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		field, ok := node.(*ast.Field)
+		if !ok || field.Tag == nil || found {
+			return !found
+		}
+
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return true
+		}
+
+		for _, tagName := range tagNames {
+			if _, ok := reflect.StructTag(tag).Lookup(tagName); ok {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// declaresTypes returns true if the given file declares any types with the given names.
+func declaresTypes(file *ast.File, typeNames []string) bool {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+
+			for _, typeName := range typeNames {
+				if typeSpec.Name.Name == typeName {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package source
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	t.Run("should return the path to a file as-is", func(t *testing.T) {
+		srcPaths, err := Find("./testdata/other.go")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"./testdata/other.go"}, srcPaths)
+	})
+
+	t.Run("should return files that declare constraints in a directory", func(t *testing.T) {
+		srcPaths, err := Find("./testdata")
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("testdata", "testdata.go")}, srcPaths)
+	})
+
+	t.Run("should return files that declare constraints in a directory, recursively", func(t *testing.T) {
+		srcPaths, err := Find("./testdata/...")
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("testdata", "testdata.go")}, srcPaths)
+	})
+
+	t.Run("should also return files with fields that have the given struct tags in a directory", func(t *testing.T) {
+		srcPaths, err := Find("./testdata", WithTags("constraints"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join("testdata", "other.go"),
+			filepath.Join("testdata", "testdata.go"),
+		}, srcPaths)
+	})
+
+	t.Run("should also return files that declare the given types in a directory", func(t *testing.T) {
+		srcPaths, err := Find("./testdata", WithTypes("TertiarySubject"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join("testdata", "other.go"),
+			filepath.Join("testdata", "testdata.go"),
+		}, srcPaths)
+	})
+
+	t.Run("should skip testdata directories when searching recursively", func(t *testing.T) {
+		srcPaths, err := Find("./...")
+		require.NoError(t, err)
+		assert.Empty(t, srcPaths)
+	})
+
+	t.Run("should error if the path doesn't exist", func(t *testing.T) {
+		_, err := Find("./testdata/nonexistent")
+		assert.Error(t, err)
+	})
+}
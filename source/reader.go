@@ -3,11 +3,17 @@ package source
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
 	"go/token"
+	"go/types"
 	"io"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 	"unicode/utf8"
@@ -15,22 +21,119 @@ import (
 	"github.com/seeruk/valley"
 )
 
-// Read attempts to read a Go file, and based on it's contents return the package name, along
-// with an extract of information about the methods and structs in that file.
-func Read(fileSet *token.FileSet, file *ast.File, srcPath string) valley.Source {
-	var source valley.Source
+// typesImporter is used to import packages when type checking. It's shared so that imported packages
+// are only type checked once, as importing packages from source is relatively slow. It has it's own
+// FileSet, as positions in imported packages are never needed.
+var typesImporter = importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+// Reader reads Go source files. Information that is the same for every source file, such as the
+// modules in use, is looked up once and shared, so one Reader should be used to read many files.
+type Reader struct {
+	modules     []valley.Module
+	modulesRead bool
+	importNames map[string]string
+}
 
-	modules := readModules()
+// NewReader returns a new Reader instance.
+func NewReader() *Reader {
+	return &Reader{
+		importNames: make(map[string]string),
+	}
+}
 
-	for _, imp := range file.Imports {
-		impPath := strings.Trim(imp.Path.Value, "\"")
+// Read attempts to read the Go package that the file at the given path belongs to, using a new
+// Reader. See Reader.Read for more information.
+func Read(fileSet *token.FileSet, srcPath string) (valley.Source, error) {
+	return NewReader().Read(fileSet, srcPath)
+}
 
-		impName, ok := readModuleName(modules, impPath)
-		if !ok {
-			// Fallback to "dumb" way of guessing package name based on path.
-			impName = path.Base(impPath)
+// Read attempts to read the Go package that the file at the given path belongs to, and based on
+// it's contents return the package name, along with an extract of information about the methods
+// and structs in every (non-test) file in that package. Imports are only read from the given file,
+// as that is the file that code will be generated for.
+func (r *Reader) Read(fileSet *token.FileSet, srcPath string) (valley.Source, error) {
+	sources, err := r.ReadPackage(fileSet, []string{srcPath})
+	if err != nil {
+		return valley.Source{}, err
+	}
+
+	return sources[0], nil
+}
+
+// ReadPackage attempts to read each of the Go files at the given paths, which should all be in the
+// same directory, returning a Source for each of them in the same order. Unlike calling Read for
+// each file, their package is only parsed and type checked once. See Reader.Read for more info.
+func (r *Reader) ReadPackage(fileSet *token.FileSet, srcPaths []string) ([]valley.Source, error) {
+	srcFiles := make([]*ast.File, 0, len(srcPaths))
+	for _, srcPath := range srcPaths {
+		file, err := parser.ParseFile(fileSet, srcPath, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse source: %v", err)
+		}
+
+		srcFiles = append(srcFiles, file)
+	}
+
+	sources := make([]valley.Source, len(srcPaths))
+	read := make([]bool, len(srcPaths))
+
+	// Files in the same directory may still be in different packages (e.g. if some are excluded by
+	// build constraints), so the files given for each package are read together.
+	for i, file := range srcFiles {
+		if read[i] {
+			continue
 		}
 
+		var pkgFiles []*ast.File
+		var pkgIndexes []int
+		for j := i; j < len(srcFiles); j++ {
+			if !read[j] && srcFiles[j].Name.Name == file.Name.Name {
+				pkgFiles = append(pkgFiles, srcFiles[j])
+				pkgIndexes = append(pkgIndexes, j)
+				read[j] = true
+			}
+		}
+
+		files, err := readPackageFiles(fileSet, srcPaths[i], pkgFiles)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, info := checkTypes(fileSet, file.Name.Name, files)
+
+		for _, j := range pkgIndexes {
+			sources[j] = r.read(fileSet, srcPaths[j], srcFiles[j], files, pkg, info)
+		}
+	}
+
+	return sources, nil
+}
+
+// ReadStandalone attempts to read the given Go source code as if it were a file with the given name,
+// without reading the rest of it's package (e.g. because it's not on disk). This means that types
+// used in the source code must either be declared in it, or imported.
+func (r *Reader) ReadStandalone(fileSet *token.FileSet, name string, src []byte) (valley.Source, error) {
+	file, err := parser.ParseFile(fileSet, name, src, parser.ParseComments)
+	if err != nil {
+		return valley.Source{}, fmt.Errorf("failed to parse source: %v", err)
+	}
+
+	files := []*ast.File{file}
+	pkg, info := checkTypes(fileSet, file.Name.Name, files)
+
+	return r.read(fileSet, name, file, files, pkg, info), nil
+}
+
+// read returns the information Valley needs about the given file, which is at the given source
+// path, using the given files (which should include the given file) as the rest of it's package,
+// and the result of type checking them.
+func (r *Reader) read(fileSet *token.FileSet, srcPath string, file *ast.File, files []*ast.File, pkg *types.Package, info *types.Info) valley.Source {
+	var source valley.Source
+
+	for _, imp := range file.Imports {
+		impPath := strings.Trim(imp.Path.Value, "\"")
+		impName := r.importName(impPath)
+
 		if imp.Name != nil {
 			impName = imp.Name.Name
 		}
@@ -44,16 +147,20 @@ func Read(fileSet *token.FileSet, file *ast.File, srcPath string) valley.Source
 	source.FileName = path.Base(srcPath)
 	source.FileSet = fileSet
 	source.Package = file.Name.Name
+	source.TypesPackage = pkg
 	source.Methods = make(valley.Methods)
+	source.Functions = make(valley.Methods)
 	source.Structs = make(valley.Structs)
 
-	if len(file.Decls) > 0 {
-		for _, decl := range file.Decls {
+	for _, f := range files {
+		fileName := filepath.Base(fileSet.Position(f.Pos()).Filename)
+
+		for _, decl := range f.Decls {
 			switch d := decl.(type) {
 			case *ast.FuncDecl:
-				readFuncDecl(d, &source)
+				readFuncDecl(d, fileName, info, &source)
 			case *ast.GenDecl:
-				readGenDecl(d, &source)
+				readGenDecl(d, fileName, info, &source)
 			}
 		}
 	}
@@ -70,6 +177,111 @@ func Read(fileSet *token.FileSet, file *ast.File, srcPath string) valley.Source
 	return source
 }
 
+// readPackageFiles parses all of the other files in the package that the given files belong to,
+// returning them alongside the given files, which are in the same directory as the given source
+// path. Test files, files excluded by build constraints, files in other packages, and files
+// previously generated by Valley are skipped.
+func readPackageFiles(fileSet *token.FileSet, srcPath string, given []*ast.File) ([]*ast.File, error) {
+	files := append([]*ast.File{}, given...)
+
+	givenNames := make(map[string]bool, len(given))
+	for _, f := range given {
+		givenNames[filepath.Base(fileSet.Position(f.Pos()).Filename)] = true
+	}
+
+	dir := filepath.Dir(srcPath)
+
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			// Only the given files are eligible, e.g. because of build constraints.
+			return files, nil
+		}
+
+		return nil, fmt.Errorf("failed to read package in %q: %v", dir, err)
+	}
+
+	for _, name := range pkg.GoFiles {
+		if givenNames[name] {
+			continue
+		}
+
+		f, err := parser.ParseFile(fileSet, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse package file: %v", err)
+		}
+
+		if f.Name.Name != given[0].Name.Name || isGenerated(f) {
+			continue
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+// checkTypes type checks the given files, which should make up an entire package. Type errors are
+// ignored, as the package may not compile whilst it's validation code is missing or out of date,
+// and partial type information is still far more useful than none.
+func checkTypes(fileSet *token.FileSet, pkgName string, files []*ast.File) (*types.Package, *types.Info) {
+	info := &types.Info{
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+		Types: make(map[ast.Expr]types.TypeAndValue),
+	}
+
+	conf := types.Config{
+		Importer: typesImporter,
+		Error:    func(error) {},
+	}
+
+	pkg, _ := conf.Check(pkgName, fileSet, files, info)
+
+	return pkg, info
+}
+
+// isGenerated returns true if the given file appears to have been generated by Valley. Generated
+// files are ignored, as they may be out of date, and will be replaced anyway.
+func isGenerated(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			if strings.HasPrefix(comment.Text, "// Code generated by valley.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// importName returns the package name for the given import path. The name is looked up using the
+// modules in use the first time an import path is seen, and is then re-used.
+func (r *Reader) importName(impPath string) string {
+	if impName, ok := r.importNames[impPath]; ok {
+		return impName
+	}
+
+	if !r.modulesRead {
+		r.modules = readModules()
+		r.modulesRead = true
+	}
+
+	impName, ok := readModuleName(r.modules, impPath)
+	if !ok {
+		// Fallback to "dumb" way of guessing package name based on path.
+		impName = path.Base(impPath)
+	}
+
+	r.importNames[impPath] = impName
+
+	return impName
+}
+
 // readModules ...
 func readModules() []valley.Module {
 	cmd := exec.Command("go", "list", "-m", "-json", "all")
@@ -140,8 +352,13 @@ func readModuleName(modules []valley.Module, impPath string) (string, bool) {
 
 // readFuncDecl reads a Go function declaration and adds contents that are relevant to the given
 // valley Source.
-func readFuncDecl(d *ast.FuncDecl, source *valley.Source) {
-	if d.Recv == nil || len(d.Recv.List) == 0 || len(d.Recv.List[0].Names) == 0 {
+func readFuncDecl(d *ast.FuncDecl, fileName string, info *types.Info, source *valley.Source) {
+	if d.Recv == nil {
+		readFunction(d, fileName, info, source)
+		return
+	}
+
+	if len(d.Recv.List) == 0 || len(d.Recv.List[0].Names) == 0 {
 		return
 	}
 
@@ -149,21 +366,134 @@ func readFuncDecl(d *ast.FuncDecl, source *valley.Source) {
 	receiverName := receiver.Names[0].Name
 	receiverType := unpackStarExpr(receiver.Type)
 
+	// Receivers of methods on generic types list the type's parameters, e.g. `Page[T]`.
+	switch t := receiverType.(type) {
+	case *ast.IndexExpr:
+		receiverType = t.X
+	case *ast.IndexListExpr:
+		receiverType = t.X
+	}
+
 	switch t := receiverType.(type) {
 	case *ast.Ident:
 		source.Methods[t.Name] = append(source.Methods[t.Name], valley.Method{
+			FileName: fileName,
 			Receiver: receiverName,
 			Name:     d.Name.Name,
 			Params:   d.Type.Params,
 			Results:  d.Type.Results,
 			Body:     d.Body,
+			Pos:      d.Name.Pos(),
 		})
 	}
 }
 
+// readFunction reads a Go function declaration that isn't a method. If it's first parameter is of a
+// named type, it's added to the functions for that type in the given valley Source. If that type is
+// a struct from another package, and the function is in the source file, the struct is also added.
+func readFunction(d *ast.FuncDecl, fileName string, info *types.Info, source *valley.Source) {
+	params := d.Type.Params
+	if params == nil || len(params.List) == 0 || len(params.List[0].Names) == 0 {
+		return
+	}
+
+	param := params.List[0]
+	paramType := unpackStarExpr(param.Type)
+
+	// Functions for generic types in this package list the type's parameters, e.g. `Page[T]`.
+	switch t := paramType.(type) {
+	case *ast.IndexExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			paramType = ident
+		}
+	case *ast.IndexListExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			paramType = ident
+		}
+	}
+
+	var typeName string
+
+	switch t := paramType.(type) {
+	case *ast.Ident:
+		typeName = t.Name
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return
+		}
+
+		typeName = pkg.Name + "." + t.Sel.Name
+
+		if fileName == source.FileName {
+			readExternalStruct(typeName, info.TypeOf(paramType), source)
+		}
+	default:
+		return
+	}
+
+	source.Functions[typeName] = append(source.Functions[typeName], valley.Method{
+		FileName: fileName,
+		Receiver: param.Names[0].Name,
+		Name:     d.Name.Name,
+		Params:   d.Type.Params,
+		Results:  d.Type.Results,
+		Body:     d.Body,
+		Pos:      d.Name.Pos(),
+	})
+}
+
+// readExternalStruct adds a struct from another package to the given valley Source, using the type
+// information that's available for it, as we don't have it's AST. The AST for the struct is instead
+// built from it's type information.
+func readExternalStruct(typeName string, typ types.Type, source *valley.Source) {
+	if _, ok := source.Structs[typeName]; ok || typ == nil {
+		return
+	}
+
+	structType, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+
+	// The AST is only used to read the struct's fields, so the imports it needs aren't.
+	expr, _ := source.TypeExpr(structType)
+
+	node, ok := expr.(*ast.StructType)
+	if !ok {
+		return
+	}
+
+	fields := readStructFields(node, nil)
+	fieldNames := make([]string, 0, len(fields))
+
+	for i := 0; i < structType.NumFields(); i++ {
+		structField := structType.Field(i)
+
+		field, ok := fields[structField.Name()]
+		if !ok {
+			continue
+		}
+
+		field.ResolvedType = structField.Type()
+		fields[structField.Name()] = field
+		fieldNames = append(fieldNames, structField.Name())
+	}
+
+	sort.Strings(fieldNames)
+
+	source.Structs[typeName] = valley.Struct{
+		Name:         typeName,
+		Node:         node,
+		ResolvedType: typ,
+		Fields:       fields,
+		FieldNames:   fieldNames,
+	}
+}
+
 // readGenDecl reads a Go generic declaration and adds contents that are relevant to the given
 // valley Source.
-func readGenDecl(d *ast.GenDecl, source *valley.Source) {
+func readGenDecl(d *ast.GenDecl, fileName string, info *types.Info, source *valley.Source) {
 	if d.Tok != token.TYPE {
 		return
 	}
@@ -177,7 +507,7 @@ func readGenDecl(d *ast.GenDecl, source *valley.Source) {
 			continue
 		}
 
-		fields := readStructFields(structType)
+		fields := readStructFields(structType, info)
 		fieldNames := make([]string, 0, len(fields))
 
 		for fieldName := range fields {
@@ -186,27 +516,55 @@ func readGenDecl(d *ast.GenDecl, source *valley.Source) {
 
 		sort.Strings(fieldNames)
 
+		var typeParams []string
+		if typeSpec.TypeParams != nil {
+			for _, field := range typeSpec.TypeParams.List {
+				for _, name := range field.Names {
+					typeParams = append(typeParams, name.Name)
+				}
+			}
+		}
+
 		// At this point, we definitely have a struct.
 		structName := typeSpec.Name.Name
 		source.Structs[structName] = valley.Struct{
-			Name:       structName,
-			Node:       structType,
-			Fields:     fields,
-			FieldNames: fieldNames,
+			FileName:     fileName,
+			Name:         structName,
+			Node:         structType,
+			ResolvedType: resolveType(info, typeSpec.Name),
+			TypeParams:   typeParams,
+			Fields:       fields,
+			FieldNames:   fieldNames,
 		}
 	}
 }
 
 // readStructFields reads information about the fields on a given struct type, returning them in a
 // more easily accessible format, with only the information we need.
-func readStructFields(structType *ast.StructType) valley.Fields {
+func readStructFields(structType *ast.StructType, info *types.Info) valley.Fields {
 	fields := make(valley.Fields)
 
 	for _, field := range structType.Fields.List {
-		for _, name := range field.Names {
+		names := field.Names
+		embedded := len(names) == 0
+
+		if embedded {
+			// Embedded fields are named after their type, and their promoted fields are looked up
+			// through them when they're used (see valley.Source.FieldPath).
+			name, ok := valley.EmbeddedFieldName(field.Type)
+			if !ok {
+				continue
+			}
+
+			names = []*ast.Ident{name}
+		}
+
+		for _, name := range names {
 			valleyField := valley.Value{
-				Name: name.Name,
-				Type: field.Type,
+				Name:         name.Name,
+				Type:         field.Type,
+				ResolvedType: resolveType(info, name),
+				Embedded:     embedded,
 			}
 
 			if field.Tag != nil {
@@ -223,6 +581,21 @@ func readStructFields(structType *ast.StructType) valley.Fields {
 	return fields
 }
 
+// resolveType returns the type of the object defined by the given identifier, if the type checker
+// was able to determine it. Otherwise, nil is returned.
+func resolveType(info *types.Info, ident *ast.Ident) types.Type {
+	if info == nil {
+		return nil
+	}
+
+	obj, ok := info.Defs[ident]
+	if !ok || obj == nil {
+		return nil
+	}
+
+	return obj.Type()
+}
+
 // unpackStarExpr ...
 // NOTE: This is purposefully _not_ recursive, as it's invalid for a method receiver to be a pointer
 // to a pointer to a type, as that would be an unnamed type.
@@ -2,7 +2,6 @@ package source
 
 import (
 	"flag"
-	"go/parser"
 	"go/token"
 	"io/ioutil"
 	"testing"
@@ -16,10 +15,9 @@ var update = flag.Bool("update", false, "update golden record files?")
 
 func TestRead(t *testing.T) {
 	fileSet := token.NewFileSet()
-	file, err := parser.ParseFile(fileSet, "./testdata/testdata.go", nil, 0)
-	require.NoError(t, err)
 
-	source := Read(fileSet, file, "./testdata/testdata.go")
+	source, err := Read(fileSet, "./testdata/testdata.go")
+	require.NoError(t, err)
 
 	t.Run("should set file name on the returned source", func(t *testing.T) {
 		assert.Equal(t, "testdata.go", source.FileName)
@@ -37,18 +35,100 @@ func TestRead(t *testing.T) {
 		require.NotNil(t, source.Structs)
 		require.NotNil(t, source.StructNames)
 
-		assert.Len(t, source.Structs, 2)
-		assert.Len(t, source.StructNames, 2)
+		assert.Len(t, source.Structs, 4)
+		assert.Len(t, source.StructNames, 4)
 	})
 
 	t.Run("should set methods on the returned source", func(t *testing.T) {
 		require.NotNil(t, source.Methods)
-		assert.Len(t, source.Methods, 2)
+		assert.Len(t, source.Methods, 3)
+	})
+
+	t.Run("should set functions on the returned source", func(t *testing.T) {
+		require.NotNil(t, source.Functions)
+		assert.Len(t, source.Functions, 2)
+		require.Contains(t, source.Functions, "image.Point")
+		assert.Equal(t, "p", source.Functions["image.Point"][0].Receiver)
+	})
+
+	t.Run("should read structs from other packages used by functions", func(t *testing.T) {
+		require.Contains(t, source.Structs, "image.Point")
+		assert.Equal(t, []string{"X", "Y"}, source.Structs["image.Point"].FieldNames)
+		assert.NotNil(t, source.Structs["image.Point"].Fields["X"].ResolvedType)
+	})
+
+	t.Run("should read structs from other files in the same package", func(t *testing.T) {
+		require.Contains(t, source.Structs, "TertiarySubject")
+		assert.Equal(t, "other.go", source.Structs["TertiarySubject"].FileName)
+	})
+
+	t.Run("should not read test files, or files generated by valley", func(t *testing.T) {
+		assert.NotContains(t, source.Structs, "TestSubject")
+		assert.NotContains(t, source.Structs, "IgnoredSubject")
+
+		for _, method := range source.Methods["Subject"] {
+			assert.NotEqual(t, "Validate", method.Name)
+		}
+	})
+
+	t.Run("should error if the source file can't be parsed", func(t *testing.T) {
+		_, err := Read(token.NewFileSet(), "./testdata/nonexistent.go")
+		assert.Error(t, err)
+	})
+
+	t.Run("should error if standalone source can't be parsed", func(t *testing.T) {
+		_, err := NewReader().ReadStandalone(token.NewFileSet(), "stdin.go", []byte("this is invalid Go"))
+		assert.Error(t, err)
+	})
+
+	t.Run("should read standalone source without the rest of it's package", func(t *testing.T) {
+		bs, err := ioutil.ReadFile("./testdata/testdata.go")
+		require.NoError(t, err)
+
+		standalone, err := NewReader().ReadStandalone(token.NewFileSet(), "stdin.go", bs)
+		require.NoError(t, err)
+
+		assert.Equal(t, "stdin.go", standalone.FileName)
+		assert.Equal(t, "testdata", standalone.Package)
+		assert.Contains(t, standalone.Structs, "Subject")
+		assert.NotContains(t, standalone.Structs, "TertiarySubject")
+		assert.Equal(t, source.Imports, standalone.Imports)
+	})
+
+	t.Run("should read generic structs, and methods and functions on them", func(t *testing.T) {
+		src := []byte(`package generic
+
+import "github.com/seeruk/valley"
+
+type Page[T any] struct {
+	Items []T
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func (p Page[T]) Constraints(t valley.Type) {}
+
+func (p *Pair[K, V]) Constraints(t valley.Type) {}
+
+func PageConstraints[T any](p *Page[T], t valley.Type) {}
+`)
+
+		generic, err := NewReader().ReadStandalone(token.NewFileSet(), "generic.go", src)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"T"}, generic.Structs["Page"].TypeParams)
+		assert.Equal(t, []string{"K", "V"}, generic.Structs["Pair"].TypeParams)
+		assert.Len(t, generic.Methods["Page"], 1)
+		assert.Len(t, generic.Methods["Pair"], 1)
+		assert.Len(t, generic.Functions["Page"], 1)
 	})
 
 	t.Run("should set imports on the returned source", func(t *testing.T) {
 		require.NotNil(t, source.Imports)
-		assert.Len(t, source.Imports, 2)
+		assert.Len(t, source.Imports, 3)
 	})
 
 	t.Run("should match the test snapshot", func(t *testing.T) {
@@ -60,7 +140,7 @@ func TestRead(t *testing.T) {
 		spewer.DisablePointerAddresses = true
 		spewer.SortKeys = true
 
-		actual := spewer.Sdump(source)
+		actual := spewer.Sdump(localSource)
 		if *update {
 			err := ioutil.WriteFile("./testdata/testdata.txt", []byte(actual), 0666)
 			require.NoError(t, err)
@@ -72,3 +152,35 @@ func TestRead(t *testing.T) {
 		assert.Equal(t, string(bs), actual)
 	})
 }
+
+func TestReader_ReadPackage(t *testing.T) {
+	fileSet := token.NewFileSet()
+
+	sources, err := NewReader().ReadPackage(fileSet, []string{"./testdata/testdata.go", "./testdata/other.go"})
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+
+	t.Run("should return a source for each file, in order", func(t *testing.T) {
+		assert.Equal(t, "testdata.go", sources[0].FileName)
+		assert.Equal(t, "other.go", sources[1].FileName)
+		assert.Len(t, sources[0].Imports, 3)
+		assert.Empty(t, sources[1].Imports)
+	})
+
+	t.Run("should read the same package as Read for each file", func(t *testing.T) {
+		source, err := Read(token.NewFileSet(), "./testdata/other.go")
+		require.NoError(t, err)
+
+		assert.Equal(t, source.StructNames, sources[1].StructNames)
+		assert.Equal(t, "other.go", sources[0].Structs["TertiarySubject"].FileName)
+	})
+
+	t.Run("should type check the package once", func(t *testing.T) {
+		assert.Same(t, sources[0].TypesPackage, sources[1].TypesPackage)
+	})
+
+	t.Run("should error if any of the source files can't be parsed", func(t *testing.T) {
+		_, err := NewReader().ReadPackage(token.NewFileSet(), []string{"./testdata/testdata.go", "./testdata/nonexistent.go"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,9 @@
+package testdata
+
+// TertiarySubject is a type declared in a different file to the one being read, used for testing
+// that source reading functionality reads the whole package.
+type TertiarySubject struct {
+	*SecondarySubject
+
+	SomeText string `constraints:"required"`
+}
@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"image"
+
 	"github.com/seeruk/valley"
 
 	// Aliased to test that import aliases are also captured.
@@ -35,6 +37,24 @@ func (s *SecondarySubject) Constraints(t valley.Type) {
 	t.Field(s.SomePtr).Constraints(c.NotNil())
 }
 
+// Constraints is a valley constraints method for a type declared in another file in this package,
+// used for testing that source reading functionality reads the whole package.
+func (s TertiarySubject) Constraints(t valley.Type) {
+	t.Field(s.SomeText).Constraints(c.Required())
+}
+
+// SubjectConstraints is a valley constraints function used for testing source reading
+// functionality.
+func SubjectConstraints(s Subject, t valley.Type) {
+	t.Field(s.SomeText).Constraints(c.Required())
+}
+
+// PointConstraints is a valley constraints function for a type declared in another package, used
+// for testing that source reading functionality reads structs from other packages.
+func PointConstraints(p image.Point, t valley.Type) {
+	t.Field(p.X).Constraints(c.Min(0))
+}
+
 // SomeVar is used to ensure generic declarations that aren't types aren't included in the
 // information read from Go source files when testing source reading functionality.
 var SomeVar = 123
@@ -45,8 +65,9 @@ type SomeInterface interface {
 	ThatIsUnused()
 }
 
-// SomeFunction is used to ensure function declarations that aren't methods aren't included in the
-// information read from Go source files when testing source reading functionality.
+// SomeFunction is used to ensure function declarations that don't accept a value of some type
+// aren't included in the information read from Go source files when testing source reading
+// functionality.
 func SomeFunction() {
 	// No-op.
 }
@@ -0,0 +1,5 @@
+package testdata
+
+// TestSubject is used to ensure test files aren't included in the information read from Go source
+// files when testing source reading functionality.
+type TestSubject struct{}
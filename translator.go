@@ -0,0 +1,262 @@
+package valley
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// catalogueFS holds the built-in catalogues, one JSON file per language.
+//
+//go:embed catalogues/*.json
+var catalogueFS embed.FS
+
+// DefaultTranslator is a Translator using the built-in catalogues, falling back to English.
+var DefaultTranslator = NewCatalogueTranslator("en", Catalogues())
+
+// Translator renders the message of a ConstraintViolation in the language of the given locale.
+type Translator interface {
+	Translate(locale string, violation ConstraintViolation) string
+}
+
+// Catalogue maps violation codes on to message templates in a single language. Templates may refer
+// to a violation's details by wrapping their JSON key in braces, e.g. "length must be at most
+// {maximum}".
+type Catalogue map[string]string
+
+// Catalogues returns a copy of the built-in catalogues, keyed by language (e.g. "en", or "de").
+// They include a message for the code of each built-in constraint, other than Predicate.
+func Catalogues() map[string]Catalogue {
+	entries, err := catalogueFS.ReadDir("catalogues")
+	if err != nil {
+		panic(fmt.Sprintf("valley: failed to read built-in catalogues: %v", err))
+	}
+
+	catalogues := make(map[string]Catalogue, len(entries))
+
+	for _, entry := range entries {
+		bs, err := catalogueFS.ReadFile(path.Join("catalogues", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("valley: failed to read built-in catalogue %q: %v", entry.Name(), err))
+		}
+
+		var catalogue Catalogue
+		if err := json.Unmarshal(bs, &catalogue); err != nil {
+			panic(fmt.Sprintf("valley: failed to parse built-in catalogue %q: %v", entry.Name(), err))
+		}
+
+		catalogues[strings.TrimSuffix(entry.Name(), ".json")] = catalogue
+	}
+
+	return catalogues
+}
+
+// CatalogueTranslator is a Translator that renders messages using a Catalogue for each supported
+// locale. The locale given to Translate may be a single language tag (e.g. "de-CH"), or the value of
+// an Accept-Language header, in which case the most preferred supported locale is used.
+//
+// Violations whose code isn't in the catalogue for the chosen locale (or in the fallback catalogue)
+// keep the message they were given when they were generated, as do those with a custom message.
+type CatalogueTranslator struct {
+	catalogues map[string]Catalogue
+	fallback   string
+}
+
+// NewCatalogueTranslator returns a new *CatalogueTranslator using the given catalogues, keyed by
+// locale, and using the catalogue for the fallback locale when no other locale is supported.
+func NewCatalogueTranslator(fallback string, catalogues map[string]Catalogue) *CatalogueTranslator {
+	translator := &CatalogueTranslator{
+		catalogues: make(map[string]Catalogue, len(catalogues)),
+		fallback:   normaliseLocale(fallback),
+	}
+
+	for locale, catalogue := range catalogues {
+		translator.catalogues[normaliseLocale(locale)] = catalogue
+	}
+
+	return translator
+}
+
+// Locales returns the locales supported by this CatalogueTranslator, in order.
+func (t *CatalogueTranslator) Locales() []string {
+	locales := make([]string, 0, len(t.catalogues))
+	for locale := range t.catalogues {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+// Negotiate returns the supported locale that best matches the given Accept-Language header value
+// (e.g. "fr-CH, fr;q=0.9, en;q=0.8"), taking quality values into account. A language tag matches a
+// locale if it's the same, or if it's language is (e.g. "de-AT" matches "de"). If no locale matches,
+// the fallback locale is returned.
+func (t *CatalogueTranslator) Negotiate(acceptLanguage string) string {
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			break
+		}
+
+		if _, ok := t.catalogues[tag]; ok {
+			return tag
+		}
+
+		if i := strings.Index(tag, "-"); i > -1 {
+			if _, ok := t.catalogues[tag[:i]]; ok {
+				return tag[:i]
+			}
+		}
+	}
+
+	return t.fallback
+}
+
+// Translate implements Translator, rendering the given violation's message using the catalogue of
+// the locale that best matches the given locale, or Accept-Language header value.
+func (t *CatalogueTranslator) Translate(locale string, violation ConstraintViolation) string {
+	if violation.CustomMessage {
+		return violation.Message
+	}
+
+	for _, locale := range []string{t.Negotiate(locale), t.fallback} {
+		if template, ok := t.catalogues[locale][violation.Code]; ok && violation.Code != "" {
+			return renderMessage(template, violation.Details)
+		}
+	}
+
+	return violation.Message
+}
+
+// TranslateAll returns a copy of the given violations, with their messages rendered by the given
+// Translator in the language of the given locale (or Accept-Language header value, if the
+// Translator supports it).
+func TranslateAll(translator Translator, locale string, violations []ConstraintViolation) []ConstraintViolation {
+	if violations == nil {
+		return nil
+	}
+
+	translated := make([]ConstraintViolation, 0, len(violations))
+	for _, violation := range violations {
+		violation.Message = translator.Translate(locale, violation)
+		translated = append(translated, violation)
+	}
+
+	return translated
+}
+
+// renderMessage replaces each placeholder in the given template with the detail of the same name.
+// Placeholders without a matching detail are left as they are.
+func renderMessage(template string, details interface{}) string {
+	params := detailParams(details)
+	if len(params) == 0 {
+		return template
+	}
+
+	var oldnew []string
+	for key, value := range params {
+		oldnew = append(oldnew, "{"+key+"}", formatDetail(value))
+	}
+
+	return strings.NewReplacer(oldnew...).Replace(template)
+}
+
+// detailParams returns the given details as a map, keyed by their JSON keys, so that details of any
+// type (including those of custom constraints) can be used in messages. Numbers are kept as they'd
+// be written in JSON. If the details aren't a JSON object, nil is returned.
+func detailParams(details interface{}) map[string]interface{} {
+	if details == nil {
+		return nil
+	}
+
+	bs, err := json.Marshal(details)
+	if err != nil {
+		return nil
+	}
+
+	var params map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&params); err != nil {
+		return nil
+	}
+
+	return params
+}
+
+// formatDetail formats the value of a violation's detail to be included in a message, listing the
+// values in slices separated by commas.
+func formatDetail(value interface{}) string {
+	if v, ok := value.([]interface{}); ok {
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			values = append(values, formatDetail(value))
+		}
+
+		return strings.Join(values, ", ")
+	}
+
+	return fmt.Sprint(value)
+}
+
+// parseAcceptLanguage returns the normalised language tags in the given Accept-Language header
+// value, ordered by their quality values, most preferred first. Tags with a quality of 0 are omitted.
+func parseAcceptLanguage(acceptLanguage string) []string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+
+	var weighted []weightedTag
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(part, ";")
+
+		tag := normaliseLocale(params[0])
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err == nil {
+				quality = q
+			}
+		}
+
+		if quality > 0 {
+			weighted = append(weighted, weightedTag{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].quality > weighted[j].quality
+	})
+
+	tags := make([]string, 0, len(weighted))
+	for _, w := range weighted {
+		tags = append(tags, w.tag)
+	}
+
+	return tags
+}
+
+// normaliseLocale returns the given locale in lowercase, using hyphens as separators (e.g. "pt_BR"
+// becomes "pt-br").
+func normaliseLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,117 @@
+package valley
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogues(t *testing.T) {
+	codes := []string{
+		CodeAnyNRequired, CodeDeepEquals, CodeEquals, CodeExactlyNRequired, CodeLength, CodeMax,
+		CodeMaxLength, CodeMin, CodeMinLength, CodeMutuallyExclusive, CodeMutuallyInclusive, CodeNil,
+		CodeNotEquals, CodeNotNil, CodeOneOf, CodeRegexp, CodeRequired, CodeTimeAfter, CodeTimeBefore,
+	}
+
+	catalogues := Catalogues()
+	require.Contains(t, catalogues, "en")
+
+	for locale, catalogue := range catalogues {
+		for _, code := range codes {
+			assert.NotEmpty(t, catalogue[code], "catalogue %q has no message for %q", locale, code)
+		}
+
+		assert.Len(t, catalogue, len(codes), "catalogue %q has unexpected messages", locale)
+	}
+}
+
+func TestCatalogueTranslator_Negotiate(t *testing.T) {
+	translator := NewCatalogueTranslator("en", map[string]Catalogue{
+		"en":    {},
+		"de":    {},
+		"pt-BR": {},
+	})
+
+	tt := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "empty", acceptLanguage: "", expected: "en"},
+		{name: "exact match", acceptLanguage: "de", expected: "de"},
+		{name: "region match", acceptLanguage: "pt_br", expected: "pt-br"},
+		{name: "language match", acceptLanguage: "de-AT", expected: "de"},
+		{name: "unsupported", acceptLanguage: "fr", expected: "en"},
+		{name: "wildcard", acceptLanguage: "*", expected: "en"},
+		{name: "ordered by quality", acceptLanguage: "fr-CH, de;q=0.8, pt-BR;q=0.9", expected: "pt-br"},
+		{name: "quality of zero", acceptLanguage: "de;q=0, fr", expected: "en"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, translator.Negotiate(tc.acceptLanguage))
+		})
+	}
+}
+
+func TestCatalogueTranslator_Translate(t *testing.T) {
+	translator := NewCatalogueTranslator("en", map[string]Catalogue{
+		"en": {
+			CodeMaxLength: "length must be at most {maximum}",
+			CodeOneOf:     "value must be one of {allowed}",
+			CodeRequired:  "a value is required",
+		},
+		"de": {
+			CodeMaxLength: "Länge darf höchstens {maximum} sein",
+		},
+	})
+
+	t.Run("should render the message using the violation's details", func(t *testing.T) {
+		violation := ConstraintViolation{
+			Code:    CodeMaxLength,
+			Message: "maximum length exceeded",
+			Details: MaxLengthDetails{Maximum: 32},
+		}
+
+		assert.Equal(t, "Länge darf höchstens 32 sein", translator.Translate("de-DE,en;q=0.5", violation))
+	})
+
+	t.Run("should list the values of slices", func(t *testing.T) {
+		violation := ConstraintViolation{
+			Code:    CodeOneOf,
+			Details: OneOfDetails{Allowed: []interface{}{"foo", 2}},
+		}
+
+		assert.Equal(t, "value must be one of foo, 2", translator.Translate("en", violation))
+	})
+
+	t.Run("should use the fallback catalogue if the code is missing", func(t *testing.T) {
+		violation := ConstraintViolation{Code: CodeRequired}
+		assert.Equal(t, "a value is required", translator.Translate("de", violation))
+	})
+
+	t.Run("should keep custom messages, even if the code is known", func(t *testing.T) {
+		violation := ConstraintViolation{Code: CodeRequired, Message: "give us your name", CustomMessage: true}
+		assert.Equal(t, "give us your name", translator.Translate("de", violation))
+	})
+
+	t.Run("should keep the message if the code is unknown", func(t *testing.T) {
+		violation := ConstraintViolation{Code: "custom", Message: "value must be a valid custom ID"}
+		assert.Equal(t, violation.Message, translator.Translate("de", violation))
+	})
+}
+
+func TestTranslateAll(t *testing.T) {
+	violations := []ConstraintViolation{
+		{Path: ".name", Code: CodeRequired, Message: "a value is required"},
+	}
+
+	translated := TranslateAll(DefaultTranslator, "fr", violations)
+	require.Len(t, translated, 1)
+	assert.Equal(t, "une valeur est obligatoire", translated[0].Message)
+	assert.Equal(t, "a value is required", violations[0].Message)
+
+	err := (&ValidationError{Violations: violations}).Translate(DefaultTranslator, "es")
+	assert.EqualError(t, err, "validation failed: .name: se requiere un valor")
+}
@@ -7,8 +7,10 @@ import (
 	"go/ast"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -18,30 +20,36 @@ import (
 // BuiltIn is a map of all of the built-in validation constraints provided by Valley. This is
 // exposed so that custom code generators can build on the set of built-in rules, and also use the
 // logic exposed. It's tricky to otherwise make Valley extensible.
+//
+// Constraints that apply to the contents of a value (e.g. MaxLength, or Regexp) are optional, i.e.
+// they're skipped if the value is empty, unless the value is Required, or marked NotEmpty. Those
+// that compare the whole value (e.g. Equals) aren't, as the empty value may be what they compare.
 var BuiltIn = map[string]valley.ConstraintGenerator{
 	"github.com/seeruk/valley/validation/constraints.AnyNRequired":      anyNRequiredGenerator,
 	"github.com/seeruk/valley/validation/constraints.DeepEquals":        deepEqualsGenerator,
 	"github.com/seeruk/valley/validation/constraints.Equals":            equalsGenerator,
 	"github.com/seeruk/valley/validation/constraints.ExactlyNRequired":  exactlyNRequiredGenerator,
-	"github.com/seeruk/valley/validation/constraints.Length":            lengthGenerator(lengthExact),
-	"github.com/seeruk/valley/validation/constraints.Max":               minMaxGenerator(max),
-	"github.com/seeruk/valley/validation/constraints.MaxLength":         lengthGenerator(lengthMax),
-	"github.com/seeruk/valley/validation/constraints.Min":               minMaxGenerator(min),
-	"github.com/seeruk/valley/validation/constraints.MinLength":         lengthGenerator(lengthMin),
+	"github.com/seeruk/valley/validation/constraints.Length":            optional(lengthGenerator(lengthExact)),
+	"github.com/seeruk/valley/validation/constraints.Max":               optional(minMaxGenerator(max)),
+	"github.com/seeruk/valley/validation/constraints.MaxLength":         optional(lengthGenerator(lengthMax)),
+	"github.com/seeruk/valley/validation/constraints.Min":               optional(minMaxGenerator(min)),
+	"github.com/seeruk/valley/validation/constraints.MinLength":         optional(lengthGenerator(lengthMin)),
 	"github.com/seeruk/valley/validation/constraints.MutuallyExclusive": mutuallyExclusiveGenerator,
 	"github.com/seeruk/valley/validation/constraints.MutuallyInclusive": mutuallyInclusiveGenerator,
 	"github.com/seeruk/valley/validation/constraints.Nil":               nilGenerator,
+	"github.com/seeruk/valley/validation/constraints.NotEmpty":          notEmptyGenerator,
 	"github.com/seeruk/valley/validation/constraints.NotEquals":         notEqualsGenerator,
 	"github.com/seeruk/valley/validation/constraints.NotNil":            notNilGenerator,
-	"github.com/seeruk/valley/validation/constraints.OneOf":             oneOfGenerator,
+	"github.com/seeruk/valley/validation/constraints.OneOf":             optional(oneOfGenerator),
+	"github.com/seeruk/valley/validation/constraints.Optional":          optionalGenerator,
 	"github.com/seeruk/valley/validation/constraints.Predicate":         predicateGenerator,
-	"github.com/seeruk/valley/validation/constraints.Regexp":            regexpGenerator,
-	"github.com/seeruk/valley/validation/constraints.RegexpString":      regexpStringGenerator,
+	"github.com/seeruk/valley/validation/constraints.Regexp":            optional(regexpGenerator),
+	"github.com/seeruk/valley/validation/constraints.RegexpString":      optional(regexpStringGenerator),
 	"github.com/seeruk/valley/validation/constraints.Required":          requiredGenerator,
-	"github.com/seeruk/valley/validation/constraints.TimeAfter":         timeGenerator(timeAfter),
-	"github.com/seeruk/valley/validation/constraints.TimeBefore":        timeGenerator(timeBefore),
-	"github.com/seeruk/valley/validation/constraints.TimeStringAfter":   timeStringGenerator(timeStringAfter),
-	"github.com/seeruk/valley/validation/constraints.TimeStringBefore":  timeStringGenerator(timeStringBefore),
+	"github.com/seeruk/valley/validation/constraints.TimeAfter":         optional(timeGenerator(timeAfter)),
+	"github.com/seeruk/valley/validation/constraints.TimeBefore":        optional(timeGenerator(timeBefore)),
+	"github.com/seeruk/valley/validation/constraints.TimeStringAfter":   optional(timeStringGenerator(timeStringAfter)),
+	"github.com/seeruk/valley/validation/constraints.TimeStringBefore":  optional(timeStringGenerator(timeStringBefore)),
 	"github.com/seeruk/valley/validation/constraints.Valid":             validGenerator,
 }
 
@@ -54,7 +62,29 @@ var (
 )
 
 // GenerateEmptinessPredicate ...
-func GenerateEmptinessPredicate(varName string, fieldType ast.Expr) (string, []valley.Import) {
+func GenerateEmptinessPredicate(varName string, fieldType ast.Expr, resolvedType types.Type) (string, []valley.Import) {
+	if resolvedType != nil {
+		switch t := resolvedType.Underlying().(type) {
+		case *types.Pointer, *types.Interface, *types.Chan, *types.Signature:
+			return fmt.Sprintf("%s == nil", varName), nil
+		case *types.Slice, *types.Map:
+			return fmt.Sprintf("len(%s) == 0", varName), nil
+		case *types.Basic:
+			switch {
+			case t.Info()&types.IsString != 0:
+				return fmt.Sprintf("len(%s) == 0", varName), nil
+			case t.Info()&types.IsNumeric != 0:
+				return fmt.Sprintf("%s == 0", varName), nil
+			case t.Info()&types.IsBoolean != 0:
+				return fmt.Sprintf("!%s", varName), nil
+			}
+		case *types.Struct:
+			if hasIsZeroMethod(resolvedType) {
+				return fmt.Sprintf("%s.IsZero()", varName), nil
+			}
+		}
+	}
+
 	switch expr := fieldType.(type) {
 	case *ast.StarExpr:
 		return fmt.Sprintf("%s == nil", varName), nil
@@ -75,15 +105,20 @@ func GenerateEmptinessPredicate(varName string, fieldType ast.Expr) (string, []v
 	return fmt.Sprintf("reflect.ValueOf(%s).IsZero()", varName), []valley.Import{{Path: "reflect", Alias: "reflect"}}
 }
 
-// GenerateStandardConstraint ...
-func GenerateStandardConstraint(ctx valley.Context, predicate, message string, details map[string]interface{}) string {
+// GenerateConstraintViolation generates the code for a typical constraint, which appends a violation
+// with the given code, message, and details if the given predicate is true. Codes are stable,
+// machine-readable identifiers for the kind of violation (e.g. "max_length"), as messages may change.
+// The code and message are replaced by any given using WithCode and WithMessage. Details is the code
+// for the violation's details (see GenerateDetails), and may be empty if there are none.
+func GenerateConstraintViolation(ctx valley.Context, predicate, code, message, details string) string {
 	constraintFormat := `
 		if %s {
 			%s
 			violations = append(violations, valley.ConstraintViolation{
 				Path: path.String(),
 				PathKind: %q,
-				Message: %q,
+				Code: %q,
+				Message: %q,%s
 				%s
 			})
 			%s
@@ -91,24 +126,70 @@ func GenerateStandardConstraint(ctx valley.Context, predicate, message string, d
 	`
 
 	var detailsCode string
-	if len(details) > 0 {
-		detailsCode += "Details: map[string]interface{}{\n"
-		for k, v := range details {
-			detailsCode += fmt.Sprintf("%q: %v,\n", k, v)
-		}
-		detailsCode += "},\n"
+	if details != "" {
+		detailsCode = fmt.Sprintf("Details: %s,", details)
 	}
 
 	return fmt.Sprintf(constraintFormat,
 		predicate,
 		ctx.BeforeViolation,
 		ctx.PathKind,
-		message,
+		ViolationCode(ctx, code),
+		ViolationMessage(ctx, message),
+		GenerateCustomMessageField(ctx),
 		detailsCode,
 		ctx.AfterViolation,
 	)
 }
 
+// GenerateStandardConstraint generates the code for a typical constraint, which appends a violation
+// with the given message and details if the given predicate is true. The values of details are Go
+// expressions, which are generated as a map[string]interface{}. The violation has no code, unless
+// one is given using WithCode; use GenerateStandardConstraintWithCode to give it one.
+func GenerateStandardConstraint(ctx valley.Context, predicate, message string, details map[string]interface{}) string {
+	return GenerateStandardConstraintWithCode(ctx, predicate, "", message, details)
+}
+
+// GenerateStandardConstraintWithCode is GenerateStandardConstraint, but also gives the violation the
+// given code (which is still replaced by any given using WithCode).
+func GenerateStandardConstraintWithCode(ctx valley.Context, predicate, code, message string, details map[string]interface{}) string {
+	var detailsCode string
+	if len(details) > 0 {
+		keys := make([]string, 0, len(details))
+		for key := range details {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		detailsCode = "map[string]interface{}{\n"
+		for _, key := range keys {
+			detailsCode += fmt.Sprintf("%q: %v,\n", key, details[key])
+		}
+		detailsCode += "}"
+	}
+
+	return GenerateConstraintViolation(ctx, predicate, code, message, detailsCode)
+}
+
+// DetailsField is a field of a details type (e.g. "Minimum" in valley.MinDetails), and the Go
+// expression that it's set to.
+type DetailsField struct {
+	Name  string
+	Value string
+}
+
+// GenerateDetails generates the code for a value of the details type with the given name (e.g.
+// "valley.MinDetails"), with each of the given fields set to their value, in the order given.
+func GenerateDetails(typeName string, fields ...DetailsField) string {
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, fmt.Sprintf("%s: %s", field.Name, field.Value))
+	}
+
+	return fmt.Sprintf("%s{%s}", typeName, strings.Join(values, ", "))
+}
+
 // GenerateVariableName ...
 func GenerateVariableName(ctx valley.Context) string {
 	re := regexp.MustCompile(`([^A-z0-9])`)
@@ -162,6 +243,65 @@ func SprintNode(fileSet *token.FileSet, node ast.Node) (string, error) {
 	return buf.String(), nil
 }
 
+// Underlying returns the underlying type of the given type, looking through a pointer if the given
+// type is one. This is useful for constraints that de-reference pointers before checking a value.
+func Underlying(typ types.Type) types.Type {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		return ptr.Elem().Underlying()
+	}
+
+	return typ.Underlying()
+}
+
+// IsBasicKind returns true if the given type's underlying type (looking through a pointer) is a basic
+// type with any of the given properties (e.g. types.IsString).
+func IsBasicKind(typ types.Type, info types.BasicInfo) bool {
+	basic, ok := Underlying(typ).(*types.Basic)
+	return ok && basic.Info()&info != 0
+}
+
+// IsTime returns true if the given type is a time.Time, or a pointer to one.
+func IsTime(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	return named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+// isNamed returns true if the given type (looking through a pointer) is a named type. It returns
+// false if the given type is nil.
+func isNamed(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	_, ok := typ.(*types.Named)
+	return ok
+}
+
+// hasIsZeroMethod returns true if the given type has an `IsZero() bool` method (e.g. time.Time).
+func hasIsZeroMethod(typ types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, "IsZero")
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+
+	return types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
 // lcfirst ...
 func lcfirst(str string) string {
 	for _, v := range str {
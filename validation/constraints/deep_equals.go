@@ -23,9 +23,7 @@ func deepEqualsGenerator(ctx valley.Context, _ ast.Expr, opts []ast.Expr) (valle
 
 	predicate := fmt.Sprintf("!reflect.DeepEqual(%s, %s)", ctx.VarName, value)
 	message := "values must be deeply equal"
-	details := map[string]interface{}{
-		"deeply_equal_to": fmt.Sprintf("%v", value),
-	}
+	details := GenerateDetails("valley.DeepEqualsDetails", DetailsField{Name: "DeeplyEqualTo", Value: value})
 
 	output.Imports = CollectExprImports(ctx, opts[0])
 	output.Imports = append(output.Imports, valley.Import{
@@ -33,7 +31,7 @@ func deepEqualsGenerator(ctx valley.Context, _ ast.Expr, opts []ast.Expr) (valle
 		Alias: "reflect",
 	})
 
-	output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+	output.Code = GenerateConstraintViolation(ctx, predicate, valley.CodeDeepEquals, message, details)
 
 	return output, nil
 }
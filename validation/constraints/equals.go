@@ -28,12 +28,10 @@ func equalsGenerator(ctx valley.Context, _ ast.Expr, opts []ast.Expr) (valley.Co
 
 	predicate := fmt.Sprintf("%s != %s", ctx.VarName, value)
 	message := "values must be equal"
-	details := map[string]interface{}{
-		"equal_to": fmt.Sprintf("%v", value),
-	}
+	details := GenerateDetails("valley.EqualsDetails", DetailsField{Name: "EqualTo", Value: value})
 
 	output.Imports = CollectExprImports(ctx, opts[0])
-	output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+	output.Code = GenerateConstraintViolation(ctx, predicate, valley.CodeEquals, message, details)
 
 	return output, nil
 }
@@ -26,11 +26,9 @@ const exactlyNRequiredFormat = `
 			violations = append(violations, valley.ConstraintViolation{
 				Path: path.String(),
 				PathKind: %q,
-				Message: "exact number of required fields not met",
-				Details: map[string]interface{}{
-					"num_required": %s,
-					"fields": %s,
-				},
+				Code: %q,
+				Message: %q,%s
+				Details: %s,
 			})
 			%s
 		}
@@ -94,7 +92,7 @@ func exactlyNRequiredGenerator(ctx valley.Context, fieldType ast.Expr, opts []as
 				return output, fmt.Errorf("failed to generate output field name: %v", err)
 			}
 
-			predicate, imports := GenerateEmptinessPredicate(fmt.Sprintf("%s.%s", ctx.VarName, name), structField.Type)
+			predicate, imports := GenerateEmptinessPredicate(fmt.Sprintf("%s.%s", ctx.VarName, name), structField.Type, structField.ResolvedType)
 			output.Imports = append(output.Imports, imports...)
 
 			aliases = append(aliases, alias)
@@ -109,17 +107,20 @@ func exactlyNRequiredGenerator(ctx valley.Context, fieldType ast.Expr, opts []as
 		quotedAliases = append(quotedAliases, fmt.Sprintf("%q", alias))
 	}
 
-	fieldDetails := "[]string{"
-	fieldDetails += strings.Join(quotedAliases, ", ")
-	fieldDetails += "}"
+	details := GenerateDetails("valley.ExactlyNRequiredDetails",
+		DetailsField{Name: "NumRequired", Value: fmt.Sprintf("int(%s)", numRequired)},
+		DetailsField{Name: "Fields", Value: "[]string{" + strings.Join(quotedAliases, ", ") + "}"},
+	)
 
 	output.Code = fmt.Sprintf(exactlyNRequiredFormat,
 		strings.Join(predicates, "\n\n"),
 		numRequired,
 		ctx.BeforeViolation,
 		ctx.PathKind,
-		numRequired,
-		fieldDetails,
+		ViolationCode(ctx, valley.CodeExactlyNRequired),
+		ViolationMessage(ctx, "exact number of required fields not met"),
+		GenerateCustomMessageField(ctx),
+		details,
 		ctx.AfterViolation,
 	)
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
 
 	"github.com/seeruk/valley"
 )
@@ -62,38 +63,59 @@ func lengthGenerator(kind lengthKind) valley.ConstraintGenerator {
 			varName = "*" + varName
 		}
 
+		var code, detailsType, detailsField string
+
 		switch kind {
 		case lengthExact:
+			code = valley.CodeLength
 			message = "exact length not met"
 			operator = "!="
+			detailsType, detailsField = "valley.LengthDetails", "Exactly"
 		case lengthMax:
+			code = valley.CodeMaxLength
 			message = "maximum length exceeded"
 			operator = ">"
+			detailsType, detailsField = "valley.MaxLengthDetails", "Maximum"
 		case lengthMin:
+			code = valley.CodeMinLength
 			message = "minimum length not met"
 			operator = "<"
+			detailsType, detailsField = "valley.MinLengthDetails", "Minimum"
 		}
 
 		predicate += fmt.Sprintf("len(%s) %s %s", varName, operator, value)
-		details := map[string]interface{}{
-			string(kind): value,
-		}
+		details := GenerateDetails(detailsType, DetailsField{
+			Name:  detailsField,
+			Value: fmt.Sprintf("int(%s)", value),
+		})
 
 		output.Imports = CollectExprImports(ctx, opts[0])
-		output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+		output.Code = GenerateConstraintViolation(ctx, predicate, code, message, details)
 
-		return output, lengthTypeCheck(fieldType)
+		return output, lengthTypeCheck(fieldType, ctx.ResolvedType)
 	}
 }
 
 // lengthTypeCheck ...
-func lengthTypeCheck(expr ast.Expr) error {
-	// This is everything that's supported by reflect.Value.Len() too. The difference here is that
-	// this doesn't support custom types that are really any of these allowed types underneath (at
-	// least not yet...)
+func lengthTypeCheck(expr ast.Expr, typ types.Type) error {
+	// This is everything that's supported by reflect.Value.Len() too. If the type has been resolved
+	// then custom types that are really any of these allowed types underneath are also supported.
+	if typ != nil {
+		switch Underlying(typ).(type) {
+		case *types.Array, *types.Chan, *types.Map, *types.Slice:
+			return nil
+		}
+
+		if IsBasicKind(typ, types.IsString) {
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch e := expr.(type) {
 	case *ast.StarExpr:
-		return lengthTypeCheck(e.X)
+		return lengthTypeCheck(e.X, nil)
 	case *ast.ArrayType:
 		return nil
 	case *ast.ChanType:
@@ -0,0 +1,65 @@
+package constraints
+
+import (
+	"errors"
+
+	"github.com/seeruk/valley"
+)
+
+// errNoViolations is returned by constraints that don't produce violations of their own if they're
+// given a message or code to use instead.
+var errNoViolations = errors.New("cannot be given a message or code, as it doesn't produce violations of it's own")
+
+// WithMessage wraps the given constraint, replacing the message of the violations it produces with
+// the given message, e.g. `WithMessage(Min(18), "you must be an adult")`. The message must be a
+// string literal.
+func WithMessage(constraint valley.Constraint, message string) valley.Constraint {
+	return constraint
+}
+
+// WithCode wraps the given constraint, replacing the code of the violations it produces with the
+// given code, e.g. `WithCode(Min(18), "adult_required")`. The code must be a string literal.
+func WithCode(constraint valley.Constraint, code string) valley.Constraint {
+	return constraint
+}
+
+// ViolationMessage returns the message that a constraint should give the violations it produces,
+// which is the given message, unless a different one was given using WithMessage.
+func ViolationMessage(ctx valley.Context, message string) string {
+	if ctx.Message != "" {
+		return ctx.Message
+	}
+
+	return message
+}
+
+// GenerateCustomMessageField generates the code for the CustomMessage field of the violations that a
+// constraint produces (on a new line, to follow the Message field), if a message was given using
+// WithMessage, so that Translators keep it. If not, an empty string is returned.
+func GenerateCustomMessageField(ctx valley.Context) string {
+	if ctx.Message == "" {
+		return ""
+	}
+
+	return "\nCustomMessage: true,"
+}
+
+// ViolationCode returns the code that a constraint should give the violations it produces, which
+// is the given code, unless a different one was given using WithCode.
+func ViolationCode(ctx valley.Context, code string) string {
+	if ctx.Code != "" {
+		return ctx.Code
+	}
+
+	return code
+}
+
+// checkNoViolations returns an error if the given Context has a message or code for violations,
+// for constraints that don't produce any.
+func checkNoViolations(ctx valley.Context) error {
+	if ctx.Message != "" || ctx.Code != "" {
+		return errNoViolations
+	}
+
+	return nil
+}
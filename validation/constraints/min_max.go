@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
 
 	"github.com/seeruk/valley"
 )
@@ -53,31 +54,44 @@ func minMaxGenerator(kind minMaxKind) valley.ConstraintGenerator {
 			varName = "*" + varName
 		}
 
+		code := valley.CodeMax
 		message := "maximum value exceeded"
 		operator := ">"
+		detailsType, detailsField := "valley.MaxDetails", "Maximum"
 
 		if kind == min {
+			code = valley.CodeMin
 			message = "minimum value not met"
 			operator = "<"
+			detailsType, detailsField = "valley.MinDetails", "Minimum"
 		}
 
 		predicate += fmt.Sprintf("%s %s %s", varName, operator, value)
-		details := map[string]interface{}{
-			string(kind): value,
-		}
+		details := GenerateDetails(detailsType, DetailsField{
+			Name:  detailsField,
+			Value: fmt.Sprintf("float64(%s)", value),
+		})
 
 		output.Imports = CollectExprImports(ctx, opts[0])
-		output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+		output.Code = GenerateConstraintViolation(ctx, predicate, code, message, details)
 
-		return output, minMaxTypeCheck(fieldType)
+		return output, minMaxTypeCheck(fieldType, ctx.ResolvedType)
 	}
 }
 
 // minMaxTypeCheck ...
-func minMaxTypeCheck(expr ast.Expr) error {
+func minMaxTypeCheck(expr ast.Expr, typ types.Type) error {
+	if typ != nil {
+		if IsBasicKind(typ, types.IsInteger|types.IsFloat) {
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch e := expr.(type) {
 	case *ast.StarExpr:
-		return minMaxTypeCheck(e.X)
+		return minMaxTypeCheck(e.X, nil)
 	case *ast.Ident:
 		switch e.Name {
 		// TODO: What about... rune, and other built-in types that alias int? For not they'll show
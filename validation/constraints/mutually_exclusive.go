@@ -27,10 +27,9 @@ const mutuallyExclusiveFormat = `
 			violations = append(violations, valley.ConstraintViolation{
 				Path: path.String(),
 				PathKind: %q,
-				Message: "fields are mutually exclusive",
-				Details: map[string]interface{}{
-					"fields": nonEmpty,
-				},
+				Code: %q,
+				Message: %q,%s
+				Details: valley.MutuallyExclusiveDetails{Fields: nonEmpty},
 			})
 			%s
 		}
@@ -88,7 +87,7 @@ func mutuallyExclusiveGenerator(ctx valley.Context, fieldType ast.Expr, opts []a
 				return output, fmt.Errorf("failed to generate output field name: %v", err)
 			}
 
-			predicate, imports := GenerateEmptinessPredicate(fmt.Sprintf("%s.%s", ctx.VarName, name), structField.Type)
+			predicate, imports := GenerateEmptinessPredicate(fmt.Sprintf("%s.%s", ctx.VarName, name), structField.Type, structField.ResolvedType)
 			output.Imports = append(output.Imports, imports...)
 
 			aliases = append(aliases, alias)
@@ -102,6 +101,9 @@ func mutuallyExclusiveGenerator(ctx valley.Context, fieldType ast.Expr, opts []a
 		strings.Join(predicates, "\n\n"),
 		ctx.BeforeViolation,
 		ctx.PathKind,
+		ViolationCode(ctx, valley.CodeMutuallyExclusive),
+		ViolationMessage(ctx, "fields are mutually exclusive"),
+		GenerateCustomMessageField(ctx),
 		ctx.AfterViolation,
 	)
 
@@ -27,10 +27,9 @@ const mutuallyInclusiveFormat = `
 			violations = append(violations, valley.ConstraintViolation{
 				Path: path.String(),
 				PathKind: %q,
-				Message: "fields are mutually inclusive",
-				Details: map[string]interface{}{
-					"fields": %s,
-				},
+				Code: %q,
+				Message: %q,%s
+				Details: %s,
 			})
 			%s
 		}
@@ -88,7 +87,7 @@ func mutuallyInclusiveGenerator(ctx valley.Context, fieldType ast.Expr, opts []a
 				return output, fmt.Errorf("failed to generate output field name: %v", err)
 			}
 
-			predicate, imports := GenerateEmptinessPredicate(fmt.Sprintf("%s.%s", ctx.VarName, name), structField.Type)
+			predicate, imports := GenerateEmptinessPredicate(fmt.Sprintf("%s.%s", ctx.VarName, name), structField.Type, structField.ResolvedType)
 			output.Imports = append(output.Imports, imports...)
 
 			aliases = append(aliases, alias)
@@ -103,16 +102,20 @@ func mutuallyInclusiveGenerator(ctx valley.Context, fieldType ast.Expr, opts []a
 		quotedAliases = append(quotedAliases, fmt.Sprintf("%q", alias))
 	}
 
-	fieldDetails := "[]string{"
-	fieldDetails += strings.Join(quotedAliases, ", ")
-	fieldDetails += "}"
+	details := GenerateDetails("valley.MutuallyInclusiveDetails", DetailsField{
+		Name:  "Fields",
+		Value: "[]string{" + strings.Join(quotedAliases, ", ") + "}",
+	})
 
 	output.Code = fmt.Sprintf(mutuallyInclusiveFormat,
 		strings.Join(predicates, "\n\n"),
 		len(opts),
 		ctx.BeforeViolation,
 		ctx.PathKind,
-		fieldDetails,
+		ViolationCode(ctx, valley.CodeMutuallyInclusive),
+		ViolationMessage(ctx, "fields are mutually inclusive"),
+		GenerateCustomMessageField(ctx),
+		details,
 		ctx.AfterViolation,
 	)
 
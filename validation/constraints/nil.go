@@ -3,6 +3,7 @@ package constraints
 import (
 	"fmt"
 	"go/ast"
+	"go/types"
 
 	"github.com/seeruk/valley"
 )
@@ -15,16 +16,26 @@ func Nil() valley.Constraint {
 // nilGenerator ...
 func nilGenerator(ctx valley.Context, fieldType ast.Expr, _ []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
 	return valley.ConstraintGeneratorOutput{
-		Code: GenerateStandardConstraint(ctx,
+		Code: GenerateConstraintViolation(ctx,
 			fmt.Sprintf("%s != nil", ctx.VarName),
+			valley.CodeNil,
 			"value must be nil",
-			nil,
+			"",
 		),
-	}, nilTypeCheck(fieldType)
+	}, nilTypeCheck(fieldType, ctx.ResolvedType)
 }
 
 // nilTypeCheck ...
-func nilTypeCheck(expr ast.Expr) error {
+func nilTypeCheck(expr ast.Expr, typ types.Type) error {
+	if typ != nil {
+		switch typ.Underlying().(type) {
+		case *types.Chan, *types.Interface, *types.Map, *types.Pointer, *types.Signature, *types.Slice:
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch expr.(type) {
 	case *ast.StarExpr:
 		return nil
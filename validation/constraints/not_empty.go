@@ -0,0 +1,19 @@
+package constraints
+
+import (
+	"go/ast"
+
+	"github.com/seeruk/valley"
+)
+
+// NotEmpty marks a value as not being optional, meaning that constraints that are normally skipped
+// if a value is empty (e.g. MaxLength, or Regexp) are applied to it even if it's empty. Unlike
+// Required, an empty value is not a violation on it's own.
+func NotEmpty() valley.Constraint {
+	return valley.Constraint{}
+}
+
+// notEmptyGenerator doesn't generate any code, NotEmpty only affects other constraints.
+func notEmptyGenerator(ctx valley.Context, _ ast.Expr, _ []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
+	return valley.ConstraintGeneratorOutput{}, checkNoViolations(ctx)
+}
@@ -28,12 +28,10 @@ func notEqualsGenerator(ctx valley.Context, fieldType ast.Expr, opts []ast.Expr)
 
 	predicate := fmt.Sprintf("%s == %s", ctx.VarName, value)
 	message := "values must not be equal"
-	details := map[string]interface{}{
-		"equal_to": value,
-	}
+	details := GenerateDetails("valley.NotEqualsDetails", DetailsField{Name: "EqualTo", Value: value})
 
 	output.Imports = CollectExprImports(ctx, opts[0])
-	output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+	output.Code = GenerateConstraintViolation(ctx, predicate, valley.CodeNotEquals, message, details)
 
 	return output, nil
 }
@@ -3,6 +3,7 @@ package constraints
 import (
 	"fmt"
 	"go/ast"
+	"go/types"
 
 	"github.com/seeruk/valley"
 )
@@ -15,16 +16,26 @@ func NotNil() valley.Constraint {
 // notNilGenerator ...
 func notNilGenerator(ctx valley.Context, fieldType ast.Expr, _ []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
 	return valley.ConstraintGeneratorOutput{
-		Code: GenerateStandardConstraint(ctx,
+		Code: GenerateConstraintViolation(ctx,
 			fmt.Sprintf("%s == nil", ctx.VarName),
+			valley.CodeNotNil,
 			"value must not be nil",
-			nil,
+			"",
 		),
-	}, notNilTypeCheck(fieldType)
+	}, notNilTypeCheck(fieldType, ctx.ResolvedType)
 }
 
 // notNilTypeCheck ...
-func notNilTypeCheck(expr ast.Expr) error {
+func notNilTypeCheck(expr ast.Expr, typ types.Type) error {
+	if typ != nil {
+		switch typ.Underlying().(type) {
+		case *types.Chan, *types.Interface, *types.Map, *types.Pointer, *types.Signature, *types.Slice:
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch expr.(type) {
 	case *ast.StarExpr:
 		return nil
@@ -37,12 +37,14 @@ func oneOfGenerator(ctx valley.Context, fieldType ast.Expr, opts []ast.Expr) (va
 		predicates = append(predicates, fmt.Sprintf("%s != %s", ctx.VarName, value))
 	}
 
-	output.Code = GenerateStandardConstraint(ctx,
+	output.Code = GenerateConstraintViolation(ctx,
 		strings.Join(predicates, " && "),
+		valley.CodeOneOf,
 		"value must be one of the allowed values",
-		map[string]interface{}{
-			"allowed": "[]interface{}{" + strings.Join(allowed, ", ") + "}",
-		},
+		GenerateDetails("valley.OneOfDetails", DetailsField{
+			Name:  "Allowed",
+			Value: "[]interface{}{" + strings.Join(allowed, ", ") + "}",
+		}),
 	)
 
 	return output, nil
@@ -0,0 +1,70 @@
+package constraints
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/seeruk/valley"
+)
+
+// Optional marks a value as optional, meaning that all of the constraints applied to it alongside
+// this one are skipped if it's empty, not only the constraints that are optional by default.
+func Optional() valley.Constraint {
+	return valley.Constraint{}
+}
+
+// optionalGenerator doesn't generate any code, Optional only affects other constraints.
+func optionalGenerator(ctx valley.Context, _ ast.Expr, _ []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
+	return valley.ConstraintGeneratorOutput{}, checkNoViolations(ctx)
+}
+
+// IsOptional returns true if a value with the given constraints applied to it may be empty, in which
+// case constraints that apply to the value's contents (e.g. MaxLength, or Regexp) are skipped if it
+// is. Values are optional unless they're Required, or marked with NotEmpty, without a condition (a
+// value that's only required When some condition is true may still be empty otherwise).
+func IsOptional(constraintConfigs []valley.ConstraintConfig) bool {
+	for _, constraintConfig := range constraintConfigs {
+		if constraintConfig.Predicate != nil {
+			continue
+		}
+
+		switch constraintConfig.Name {
+		case "github.com/seeruk/valley/validation/constraints.NotEmpty",
+			"github.com/seeruk/valley/validation/constraints.Required":
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsMarkedOptional returns true if the given constraints include Optional, in which case all of the
+// constraints applied to the value should be skipped if it's empty. Optional can't be used inside
+// When, as it applies to every constraint on the value, so a conditional Optional doesn't count.
+func IsMarkedOptional(constraintConfigs []valley.ConstraintConfig) bool {
+	for _, constraintConfig := range constraintConfigs {
+		if constraintConfig.Predicate == nil && constraintConfig.Name == "github.com/seeruk/valley/validation/constraints.Optional" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// optional wraps the given ConstraintGenerator so that the code it generates is skipped if the value
+// being validated is empty, as long as that value is optional (see valley.Context).
+func optional(generator valley.ConstraintGenerator) valley.ConstraintGenerator {
+	return func(ctx valley.Context, fieldType ast.Expr, opts []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
+		output, err := generator(ctx, fieldType, opts)
+		if !ctx.Optional || output.Code == "" {
+			return output, err
+		}
+
+		predicate, imports := GenerateEmptinessPredicate(ctx.VarName, fieldType, ctx.ResolvedType)
+
+		output.Imports = append(output.Imports, imports...)
+		output.Code = fmt.Sprintf("if !(%s) {\n%s\n}", predicate, output.Code)
+
+		return output, err
+	}
+}
@@ -34,7 +34,7 @@ func predicateGenerator(ctx valley.Context, fieldType ast.Expr, opts []ast.Expr)
 	output.Imports = append(output.Imports, CollectExprImports(ctx, opts[0])...)
 	output.Imports = append(output.Imports, CollectExprImports(ctx, opts[1])...)
 	// TODO: Details?
-	output.Code = GenerateStandardConstraint(ctx, predicate, message, nil)
+	output.Code = GenerateConstraintViolation(ctx, predicate, valley.CodePredicate, message, "")
 
 	return output, nil
 }
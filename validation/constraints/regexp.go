@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
 	"regexp"
 
 	"github.com/seeruk/valley"
@@ -37,23 +38,37 @@ func regexpGenerator(ctx valley.Context, fieldType ast.Expr, opts []ast.Expr) (v
 		varName = "*" + varName
 	}
 
+	// Named string types must be converted to be matched.
+	if isNamed(ctx.ResolvedType) {
+		varName = fmt.Sprintf("string(%s)", varName)
+	}
+
 	predicate += fmt.Sprintf("!%s.MatchString(%s)", patternSelector, varName)
 	message := "value must match regular expression"
-	details := map[string]interface{}{
-		"regexp": fmt.Sprintf("%s.String()", patternSelector),
-	}
+	details := GenerateDetails("valley.RegexpDetails", DetailsField{
+		Name:  "Regexp",
+		Value: fmt.Sprintf("%s.String()", patternSelector),
+	})
 
 	output.Imports = CollectExprImports(ctx, opts[0])
-	output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+	output.Code = GenerateConstraintViolation(ctx, predicate, valley.CodeRegexp, message, details)
 
-	return output, regexpTypeCheck(fieldType)
+	return output, regexpTypeCheck(fieldType, ctx.ResolvedType)
 }
 
 // regexpStringTypeCheck ...
-func regexpTypeCheck(expr ast.Expr) error {
+func regexpTypeCheck(expr ast.Expr, typ types.Type) error {
+	if typ != nil {
+		if IsBasicKind(typ, types.IsString) {
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch e := expr.(type) {
 	case *ast.StarExpr:
-		return regexpTypeCheck(e.X)
+		return regexpTypeCheck(e.X, nil)
 	case *ast.SelectorExpr:
 		return nil
 	case *ast.Ident:
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
 
 	"github.com/seeruk/valley"
 )
@@ -43,11 +44,17 @@ func regexpStringGenerator(ctx valley.Context, fieldType ast.Expr, opts []ast.Ex
 		varName = "*" + varName
 	}
 
+	// Named string types must be converted to be matched.
+	if isNamed(ctx.ResolvedType) {
+		varName = fmt.Sprintf("string(%s)", varName)
+	}
+
 	predicate += fmt.Sprintf("!%s.MatchString(%s)", patternVarName, varName)
 	message := "value must match regular expression"
-	details := map[string]interface{}{
-		"regexp": fmt.Sprintf("%s.String()", patternVarName),
-	}
+	details := GenerateDetails("valley.RegexpDetails", DetailsField{
+		Name:  "Regexp",
+		Value: fmt.Sprintf("%s.String()", patternVarName),
+	})
 
 	output.Imports = CollectExprImports(ctx, opts[0])
 	output.Imports = append(output.Imports, valley.Import{
@@ -55,16 +62,24 @@ func regexpStringGenerator(ctx valley.Context, fieldType ast.Expr, opts []ast.Ex
 		Alias: "regexp",
 	})
 
-	output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+	output.Code = GenerateConstraintViolation(ctx, predicate, valley.CodeRegexp, message, details)
 
-	return output, regexpStringTypeCheck(fieldType)
+	return output, regexpStringTypeCheck(fieldType, ctx.ResolvedType)
 }
 
 // regexpStringTypeCheck ...
-func regexpStringTypeCheck(expr ast.Expr) error {
+func regexpStringTypeCheck(expr ast.Expr, typ types.Type) error {
+	if typ != nil {
+		if IsBasicKind(typ, types.IsString) {
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch e := expr.(type) {
 	case *ast.StarExpr:
-		return regexpStringTypeCheck(e.X)
+		return regexpStringTypeCheck(e.X, nil)
 	case *ast.Ident:
 		if e.Name == "string" {
 			return nil
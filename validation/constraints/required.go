@@ -13,9 +13,9 @@ func Required() valley.Constraint {
 
 // requiredGenerator ...
 func requiredGenerator(ctx valley.Context, fieldType ast.Expr, _ []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
-	predicate, imports := GenerateEmptinessPredicate(ctx.VarName, fieldType)
+	predicate, imports := GenerateEmptinessPredicate(ctx.VarName, fieldType, ctx.ResolvedType)
 	return valley.ConstraintGeneratorOutput{
 		Imports: imports,
-		Code:    GenerateStandardConstraint(ctx, predicate, "a value is required", nil),
+		Code:    GenerateConstraintViolation(ctx, predicate, valley.CodeRequired, "a value is required", ""),
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
 	"time"
 
 	"github.com/seeruk/valley"
@@ -52,7 +53,11 @@ func timeGenerator(kind timeKind) valley.ConstraintGenerator {
 		}
 
 		// TODO: These messages aren't great - any way to improve them?
+		code := valley.CodeTimeBefore
+		detailsType := "valley.TimeBeforeDetails"
 		if kind == timeAfter {
+			code = valley.CodeTimeAfter
+			detailsType = "valley.TimeAfterDetails"
 			message = "value must be after time"
 			predicate += fmt.Sprintf("!%s.After(%s)", varName, timeSelector)
 		} else {
@@ -60,9 +65,10 @@ func timeGenerator(kind timeKind) valley.ConstraintGenerator {
 			predicate += fmt.Sprintf("!%s.Before(%s)", varName, timeSelector)
 		}
 
-		details := map[string]interface{}{
-			"time": fmt.Sprintf("%s.Format(time.RFC3339)", timeSelector),
-		}
+		details := GenerateDetails(detailsType, DetailsField{
+			Name:  "Time",
+			Value: fmt.Sprintf("%s.Format(time.RFC3339)", timeSelector),
+		})
 
 		output.Imports = CollectExprImports(ctx, opts[0])
 		output.Imports = append(output.Imports, valley.Import{
@@ -70,17 +76,25 @@ func timeGenerator(kind timeKind) valley.ConstraintGenerator {
 			Alias: "time",
 		})
 
-		output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+		output.Code = GenerateConstraintViolation(ctx, predicate, code, message, details)
 
-		return output, timeTypeCheck(fieldType)
+		return output, timeTypeCheck(fieldType, ctx.ResolvedType)
 	}
 }
 
 // timeStringTypeCheck ...
-func timeTypeCheck(expr ast.Expr) error {
+func timeTypeCheck(expr ast.Expr, typ types.Type) error {
+	if typ != nil {
+		if IsTime(typ) {
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch e := expr.(type) {
 	case *ast.StarExpr:
-		return timeTypeCheck(e)
+		return timeTypeCheck(e.X, nil)
 	case *ast.SelectorExpr:
 		return nil
 	}
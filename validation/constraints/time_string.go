@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	"github.com/seeruk/valley"
 )
@@ -58,7 +59,11 @@ func timeStringGenerator(kind timeStringKind) valley.ConstraintGenerator {
 		}
 
 		// TODO: These messages aren't great - any way to improve them?
+		code := valley.CodeTimeBefore
+		detailsType := "valley.TimeBeforeDetails"
 		if kind == timeStringAfter {
+			code = valley.CodeTimeAfter
+			detailsType = "valley.TimeAfterDetails"
 			message = "value must be after time"
 			predicate += fmt.Sprintf("!%s.After(%s)", varName, timeVarName)
 		} else {
@@ -66,9 +71,10 @@ func timeStringGenerator(kind timeStringKind) valley.ConstraintGenerator {
 			predicate += fmt.Sprintf("!%s.Before(%s)", varName, timeVarName)
 		}
 
-		details := map[string]interface{}{
-			"time": fmt.Sprintf("%s.Format(time.RFC3339)", timeVarName),
-		}
+		details := GenerateDetails(detailsType, DetailsField{
+			Name:  "Time",
+			Value: fmt.Sprintf("%s.Format(time.RFC3339)", timeVarName),
+		})
 
 		output.Imports = CollectExprImports(ctx, opts[0])
 		output.Imports = append(output.Imports, valley.Import{
@@ -76,17 +82,25 @@ func timeStringGenerator(kind timeStringKind) valley.ConstraintGenerator {
 			Alias: "time",
 		})
 
-		output.Code = GenerateStandardConstraint(ctx, predicate, message, details)
+		output.Code = GenerateConstraintViolation(ctx, predicate, code, message, details)
 
-		return output, timeStringTypeCheck(fieldType)
+		return output, timeStringTypeCheck(fieldType, ctx.ResolvedType)
 	}
 }
 
 // timeStringTypeCheck ...
-func timeStringTypeCheck(expr ast.Expr) error {
+func timeStringTypeCheck(expr ast.Expr, typ types.Type) error {
+	if typ != nil {
+		if IsTime(typ) {
+			return nil
+		}
+
+		return ErrTypeWarning
+	}
+
 	switch e := expr.(type) {
 	case *ast.StarExpr:
-		return timeStringTypeCheck(e)
+		return timeStringTypeCheck(e.X, nil)
 	case *ast.SelectorExpr:
 		return nil
 	case *ast.BasicLit:
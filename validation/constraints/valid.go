@@ -2,38 +2,193 @@ package constraints
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
 
 	"github.com/seeruk/valley"
 )
 
 // Valid ...
-func Valid() valley.Constraint {
+//
+// By default the `Validate` method is called on the value. The name of a different generated
+// validation method may be given instead, e.g. `Valid("ValidateCreate")`.
+func Valid(method ...string) valley.Constraint {
 	return valley.Constraint{}
 }
 
 // validGenerator ...
-func validGenerator(ctx valley.Context, fieldType ast.Expr, _ []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
+func validGenerator(ctx valley.Context, fieldType ast.Expr, opts []ast.Expr) (valley.ConstraintGeneratorOutput, error) {
+	var output valley.ConstraintGeneratorOutput
+
+	if err := checkNoViolations(ctx); err != nil {
+		return output, err
+	}
+
+	method, err := validMethodName(opts)
+	if err != nil {
+		return output, err
+	}
+
 	buf := &bytes.Buffer{}
 
+	structType := fieldType
+	if star, ok := fieldType.(*ast.StarExpr); ok {
+		structType = star.X
+	}
+
+	if _, ok := structType.(*ast.StructType); ok {
+		// Anonymous inline structs can't have methods, so there's nothing to call.
+		return output, errors.New("cannot be used on an anonymous struct, configure it's fields directly instead")
+	}
+
+	if typeParam, ok := ctx.ResolvedType.(*types.TypeParam); ok && !HasValidationMethod(typeParam, method) {
+		return output, fmt.Errorf("the constraint of type parameter %s has no %s method", typeParam.Obj().Name(), method)
+	}
+
+	if isInterface(ctx.ResolvedType, fieldType) {
+		// The concrete type isn't known until runtime, so it's only validated if it can be.
+		fmt.Fprintln(buf, "{")
+		fmt.Fprintln(buf, ctx.BeforeViolation)
+
+		if method == "Validate" {
+			fmt.Fprintf(buf, "violations = append(violations, valley.ValidateAt(%s, path)...)\n", ctx.VarName)
+		} else {
+			// Like valley.ValidateAt, nil pointers are skipped, as their methods may not handle them.
+			fmt.Fprintf(buf, "if v, ok := %s.(interface{ %s(*valley.Path) []valley.ConstraintViolation }); ok && !valley.IsNilPointer(%s) {\n", ctx.VarName, method, ctx.VarName)
+			fmt.Fprintf(buf, "violations = append(violations, v.%s(path)...)\n", method)
+			fmt.Fprintln(buf, "}")
+		}
+
+		fmt.Fprintln(buf, ctx.AfterViolation)
+		fmt.Fprintln(buf, "}")
+
+		output.Code = buf.String()
+
+		return output, nil
+	}
+
 	_, isPointer := fieldType.(*ast.StarExpr)
 
-	// If we have a pointer to a struct, unpack it and write an if statement.
+	// If we have a pointer to a struct, unpack it and write an if statement. Otherwise, a block is
+	// still needed, so that variables declared by ctx.BeforeViolation don't clash with others.
 	if isPointer {
 		fmt.Fprintf(buf, "if %s != nil {\n", ctx.VarName)
+	} else {
+		fmt.Fprintln(buf, "{")
 	}
 
 	fmt.Fprintln(buf, ctx.BeforeViolation)
-	fmt.Fprintf(buf, "violations = append(violations, %s.Validate(path)...)\n", ctx.VarName)
+	fmt.Fprintf(buf, "violations = append(violations, %s.%s(path)...)\n", ctx.VarName, method)
 	fmt.Fprintln(buf, ctx.AfterViolation)
+	fmt.Fprintln(buf, "}")
 
-	// If we have a pointer to a struct, unpack it and write an if statement.
-	if isPointer {
-		fmt.Fprintf(buf, "}\n")
+	output.Code = buf.String()
+
+	return output, nil
+}
+
+// isInterface returns true if a value of the given type is an interface (other than a type
+// parameter, which is constrained by one), using the given type information if it's available.
+func isInterface(resolvedType types.Type, typ ast.Expr) bool {
+	if resolvedType != nil {
+		if _, ok := resolvedType.(*types.TypeParam); ok {
+			return false
+		}
+
+		_, ok := resolvedType.Underlying().(*types.Interface)
+		return ok
+	}
+
+	switch t := typ.(type) {
+	case *ast.InterfaceType:
+		return true
+	case *ast.Ident:
+		return t.Name == "any"
+	}
+
+	return false
+}
+
+// validMethodName returns the name of the validation method that should be called by the code that
+// validGenerator produces, based on the options given to Valid.
+func validMethodName(opts []ast.Expr) (string, error) {
+	if len(opts) == 0 {
+		return "Validate", nil
 	}
 
-	return valley.ConstraintGeneratorOutput{
-		Code: buf.String(),
-	}, nil
+	if len(opts) > 1 {
+		return "", errors.New("expected at most one option")
+	}
+
+	lit, ok := opts[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", errors.New("expected method name to be a string literal")
+	}
+
+	method, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to read method name: %v", err)
+	}
+
+	if !token.IsIdentifier(method) {
+		return "", fmt.Errorf("method name %q is not a valid identifier", method)
+	}
+
+	return method, nil
+}
+
+// HasValid returns true if the given constraints include Valid.
+func HasValid(constraintConfigs []valley.ConstraintConfig) bool {
+	for _, constraintConfig := range constraintConfigs {
+		if constraintConfig.Name == "github.com/seeruk/valley/validation/constraints.Valid" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasValidationMethod returns true if the given type has a validation method with the given name,
+// i.e. `func(*valley.Path) []valley.ConstraintViolation`, that can be called on an addressable
+// value of that type. For type parameters, the method must be part of their constraint.
+func HasValidationMethod(typ types.Type, method string) bool {
+	if _, ok := typ.(*types.Pointer); !ok {
+		if _, ok := typ.(*types.TypeParam); !ok {
+			typ = types.NewPointer(typ)
+		}
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(typ, false, nil, method)
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	signature, ok := fn.Type().(*types.Signature)
+	if !ok || signature.Params().Len() != 1 || signature.Results().Len() != 1 {
+		return false
+	}
+
+	ptr, ok := signature.Params().At(0).Type().(*types.Pointer)
+	if !ok || !isValleyType(ptr.Elem(), "Path") {
+		return false
+	}
+
+	slice, ok := signature.Results().At(0).Type().(*types.Slice)
+
+	return ok && isValleyType(slice.Elem(), "ConstraintViolation")
+}
+
+// isValleyType returns true if the given type is the named type with the given name in Valley's
+// package.
+func isValleyType(typ types.Type, name string) bool {
+	named, ok := typ.(*types.Named)
+
+	return ok && named.Obj().Name() == name && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == valley.ImportPath
 }
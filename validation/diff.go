@@ -0,0 +1,199 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change in a unified diff.
+const diffContext = 3
+
+// Diff returns a unified diff between the given old and new contents, using the given names in the
+// diff's header. If the contents are the same, an empty string is returned.
+func Diff(oldName, newName string, old, new []byte) string {
+	if bytes.Equal(old, new) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(old), splitLines(new))
+
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "--- %s\n", oldName)
+	fmt.Fprintf(buf, "+++ %s\n", newName)
+
+	for start := 0; start < len(ops); {
+		// Find the next change, if there is one.
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+
+		if start >= len(ops) {
+			break
+		}
+
+		// Find the end of the hunk, merging changes that are close enough to share context.
+		end := start
+		for i := start; i < len(ops) && i-end <= 2*diffContext+1; i++ {
+			if ops[i].kind != ' ' {
+				end = i
+			}
+		}
+
+		hunkStart := maxInt(start-diffContext, 0)
+		hunkEnd := minInt(end+diffContext+1, len(ops))
+
+		writeHunk(buf, ops[hunkStart:hunkEnd])
+
+		start = hunkEnd
+	}
+
+	return buf.String()
+}
+
+// diffOp is a single line in a diff, with a kind of ' ' (unchanged), '-' (removed), or '+' (added).
+// The old and new line numbers are the numbers of the lines before this line in each file.
+type diffOp struct {
+	kind    byte
+	line    string
+	oldLine int
+	newLine int
+}
+
+// writeHunk writes a single unified diff hunk containing the given lines.
+func writeHunk(buf *bytes.Buffer, ops []diffOp) {
+	var oldCount, newCount int
+
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldCount++
+		}
+
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n",
+		hunkRange(ops[0].oldLine, oldCount),
+		hunkRange(ops[0].newLine, newCount),
+	)
+
+	for _, op := range ops {
+		fmt.Fprintf(buf, "%c%s\n", op.kind, op.line)
+	}
+}
+
+// hunkRange formats the range of lines in one of the files in a hunk. The given start is the
+// number of lines before the hunk in that file.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffLines returns the operations that turn the old lines into the new lines, based on the longest
+// common subsequence of lines between them.
+func diffLines(old, new []string) []diffOp {
+	// Common lines at the start and end are trimmed first, as they're generally the vast majority
+	// of the lines in generated code, and they make the LCS table much larger.
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+
+	a := old[prefix : len(old)-suffix]
+	b := new[prefix : len(new)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = maxInt(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(old)+len(new))
+
+	oldLine, newLine := 0, 0
+	add := func(kind byte, line string) {
+		ops = append(ops, diffOp{kind: kind, line: line, oldLine: oldLine, newLine: newLine})
+
+		if kind != '+' {
+			oldLine++
+		}
+
+		if kind != '-' {
+			newLine++
+		}
+	}
+
+	for _, line := range old[:prefix] {
+		add(' ', line)
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			add(' ', a[i])
+			i++
+			j++
+		case j >= len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			add('-', a[i])
+			i++
+		default:
+			add('+', b[j])
+			j++
+		}
+	}
+
+	for _, line := range old[len(old)-suffix:] {
+		add(' ', line)
+	}
+
+	return ops
+}
+
+// splitLines splits the given contents into lines, without their line endings.
+func splitLines(bs []byte) []string {
+	if len(bs) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(bs), "\n"), "\n")
+}
+
+// maxInt returns the larger of the given ints.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// minInt returns the smaller of the given ints.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
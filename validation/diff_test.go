@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	lines := func(ls ...string) []byte {
+		return []byte(strings.Join(ls, "\n") + "\n")
+	}
+
+	t.Run("should return an empty string if the contents are the same", func(t *testing.T) {
+		assert.Equal(t, "", Diff("a", "b", lines("foo", "bar"), lines("foo", "bar")))
+	})
+
+	t.Run("should show changed lines with surrounding context", func(t *testing.T) {
+		old := lines("1", "2", "3", "4", "5", "6", "7", "8", "9")
+		new := lines("1", "2", "3", "4", "five", "6", "7", "8", "9")
+
+		expected := strings.Join([]string{
+			"--- a",
+			"+++ b",
+			"@@ -2,7 +2,7 @@",
+			" 2",
+			" 3",
+			" 4",
+			"-5",
+			"+five",
+			" 6",
+			" 7",
+			" 8",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, Diff("a", "b", old, new))
+	})
+
+	t.Run("should produce separate hunks for changes that are far apart", func(t *testing.T) {
+		old := lines("1", "2", "3", "4", "5", "6", "7", "8", "9", "10")
+		new := lines("one", "2", "3", "4", "5", "6", "7", "8", "9", "ten")
+
+		expected := strings.Join([]string{
+			"--- a",
+			"+++ b",
+			"@@ -1,4 +1,4 @@",
+			"-1",
+			"+one",
+			" 2",
+			" 3",
+			" 4",
+			"@@ -7,4 +7,4 @@",
+			" 7",
+			" 8",
+			" 9",
+			"-10",
+			"+ten",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, Diff("a", "b", old, new))
+	})
+
+	t.Run("should merge changes that are close together into one hunk", func(t *testing.T) {
+		old := lines("1", "2", "3", "4", "5", "6", "7", "8")
+		new := lines("one", "2", "3", "4", "5", "6", "7", "eight")
+
+		actual := Diff("a", "b", old, new)
+
+		assert.Equal(t, 1, strings.Count(actual, "@@ -"))
+		assert.Contains(t, actual, "@@ -1,8 +1,8 @@")
+	})
+
+	t.Run("should show additions to an empty file", func(t *testing.T) {
+		expected := strings.Join([]string{
+			"--- a",
+			"+++ b",
+			"@@ -0,0 +1,2 @@",
+			"+foo",
+			"+bar",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, Diff("a", "b", nil, lines("foo", "bar")))
+	})
+
+	t.Run("should show removed lines", func(t *testing.T) {
+		expected := strings.Join([]string{
+			"--- a",
+			"+++ b",
+			"@@ -1,3 +1,2 @@",
+			" foo",
+			"-bar",
+			" baz",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, Diff("a", "b", lines("foo", "bar", "baz"), lines("foo", "baz")))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
 	"io"
 	"sort"
 	"strings"
@@ -18,16 +19,69 @@ import (
 type Generator struct {
 	constraints   map[string]valley.ConstraintGenerator
 	constraintNum int
+	reporter      valley.Reporter
+	strict        bool
+	validEmbedded bool
+	autoValid     bool
+	validateErr   bool
 
 	cb   *bytes.Buffer
 	ipts map[valley.Import]struct{}
 	vars map[valley.Variable]struct{}
 }
 
-// NewGenerator returns a new Generator instance.
-func NewGenerator(constraints map[string]valley.ConstraintGenerator) *Generator {
-	return &Generator{
+// Option is a function that configures a Generator.
+type Option func(g *Generator)
+
+// WithReporter returns an Option that sets the Reporter that warnings found whilst generating code
+// are reported to. By default, warnings are ignored.
+func WithReporter(reporter valley.Reporter) Option {
+	return func(g *Generator) {
+		g.reporter = reporter
+	}
+}
+
+// WithStrict returns an Option that, if strict is true, makes warnings found whilst generating code
+// be returned as errors instead, so that constraints that may not work aren't silently generated.
+func WithStrict(strict bool) Option {
+	return func(g *Generator) {
+		g.strict = strict
+	}
+}
+
+// WithValidEmbedded returns an Option that, if valid is true, makes each generated validation
+// method also call the validation method of the same name on each embedded struct that has one (as
+// if it were given the Valid constraint), so that violations in embedded structs are included.
+func WithValidEmbedded(valid bool) Option {
+	return func(g *Generator) {
+		g.validEmbedded = valid
+	}
+}
+
+// WithAutoValid returns an Option that, if valid is true, makes each generated validation method
+// also call the validation method of the same name on each field, slice or array element, and map
+// value whose type has one (as if it were given the Valid constraint), so that nested types are
+// never left unvalidated by mistake. This includes embedded structs (see WithValidEmbedded).
+func WithAutoValid(valid bool) Option {
+	return func(g *Generator) {
+		g.autoValid = valid
+	}
+}
+
+// WithValidateErr returns an Option that, if validateErr is true, makes a companion method (or
+// function) be generated alongside each validation method, with an "Err" suffix (e.g.
+// ValidateErr), that returns any violations as a *valley.ValidationError instead.
+func WithValidateErr(validateErr bool) Option {
+	return func(g *Generator) {
+		g.validateErr = validateErr
+	}
+}
+
+// NewGenerator returns a new Generator instance, configured with the given options.
+func NewGenerator(constraints map[string]valley.ConstraintGenerator, opts ...Option) *Generator {
+	g := &Generator{
 		constraints: constraints,
+		reporter:    valley.DiscardReporter,
 		cb:          &bytes.Buffer{},
 		ipts: map[valley.Import]struct{}{
 			{Path: "fmt", Alias: "fmt"}:                         {},
@@ -36,6 +90,12 @@ func NewGenerator(constraints map[string]valley.ConstraintGenerator) *Generator
 		},
 		vars: make(map[valley.Variable]struct{}),
 	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
 }
 
 // Generate attempts to generate the code (returned as bytes) to validate code in the given package,
@@ -49,13 +109,26 @@ func (g *Generator) Generate(config valley.Config, source valley.Source, tagName
 	// Ensure we generate methods in the same order each time.
 	sort.Strings(typeNames)
 
-	for _, typeName := range typeNames {
-		err := g.generateType(config, source, tagName, typeName)
+	if g.validateErr {
+		err := checkValidateErrNames(config, typeNames)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	for _, typeName := range typeNames {
+		for _, typeConfig := range config.Types[typeName] {
+			if g.autoValid || g.validEmbedded {
+				typeConfig = autoValidConfig(config, source, typeName, typeConfig, !g.autoValid)
+			}
+
+			err := g.generateType(typeConfig, source, tagName, typeName)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	buf := &bytes.Buffer{}
 
 	fmt.Fprintln(buf, "// Code generated by valley. DO NOT EDIT.")
@@ -109,30 +182,79 @@ func (g *Generator) Generate(config valley.Config, source valley.Source, tagName
 	return buf.Bytes(), nil
 }
 
-// generateType generates the entire Validate method for a particular type (found in the given
-// package with the given type name.
-func (g *Generator) generateType(config valley.Config, source valley.Source, tagName, typeName string) error {
-	typ := config.Types[typeName]
-
+// generateType generates an entire validation method for a particular type (found in the given
+// package with the given type name), using the given type configuration.
+func (g *Generator) generateType(typ valley.TypeConfig, source valley.Source, tagName, typeName string) error {
 	s, ok := source.Structs[typeName]
 	if !ok {
 		return nil
 	}
 
-	// Figure out an "okay" receiver name, based on the first letter of the type.
-	firstRune, _ := utf8.DecodeRuneInString(typeName)
-	receiver := strings.ToLower(string(firstRune))
+	methodName := typ.Name
+	if methodName == "" {
+		methodName = "Validate"
+	}
+
+	// Use the receiver name from the configuration if there is one, as that's what expressions in
+	// the configuration will be using. Otherwise figure out an "okay" receiver name, based on the
+	// first letter of the type.
+	receiver := typ.Receiver
+	if receiver == "" {
+		firstRune, _ := utf8.DecodeRuneInString(typeName)
+		receiver = strings.ToLower(string(firstRune))
+	}
 
-	mm, ok := source.Methods[typeName]
-	if ok && len(mm) > 0 {
-		receiver = mm[0].Receiver
+	// Methods on generic types must list the type's parameters in their receiver.
+	receiverType := typeName
+	if len(s.TypeParams) > 0 {
+		receiverType = fmt.Sprintf("%s[%s]", typeName, strings.Join(s.TypeParams, ", "))
 	}
 
-	g.wcf("// Validate validates this %s.\n", typeName)
-	g.wc("// This method was generated by Valley.\n")
-	g.wcf("func (%s %s) Validate(path *valley.Path) []valley.ConstraintViolation {\n", receiver, typeName)
+	var typeParams string
+
+	if typ.Function {
+		// Functions for generic types must declare the type's parameters themselves.
+		if len(s.TypeParams) > 0 {
+			var err error
+			typeParams, err = g.typeParamsDecl(source, s)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Types from other packages are referred to by their package name, so we'll need to import
+		// that package in the generated code too.
+		if i := strings.Index(typeName, "."); i > -1 {
+			for _, ipt := range source.Imports {
+				if ipt.Alias == typeName[:i] {
+					g.ipts[ipt] = struct{}{}
+				}
+			}
+		}
+
+		// Functions accept whatever their constraints function did, so a pointer stays a pointer.
+		if typ.Pointer {
+			receiverType = "*" + receiverType
+		}
+
+		g.wcf("// %s validates the given %s.\n", methodName, typeName)
+		g.wc("// This function was generated by Valley.\n")
+		g.wcf("func %s%s(%s %s, path *valley.Path) []valley.ConstraintViolation {\n", methodName, typeParams, receiver, receiverType)
+	} else {
+		g.wcf("// %s validates this %s.\n", methodName, typeName)
+		g.wc("// This method was generated by Valley.\n")
+		g.wcf("func (%s %s) %s(path *valley.Path) []valley.ConstraintViolation {\n", receiver, receiverType, methodName)
+	}
 	g.wc("	var violations []valley.ConstraintViolation\n")
 	g.wc("\n")
+
+	// There's nothing to validate if a function is given a nil pointer.
+	if typ.Function && typ.Pointer {
+		g.wcf("	if %s == nil {\n", receiver)
+		g.wc("		return violations\n")
+		g.wc("	}\n\n")
+	}
+
 	g.wc("	path.Write(\".\")\n\n")
 
 	ctx := valley.Context{
@@ -145,8 +267,9 @@ func (g *Generator) generateType(config valley.Config, source valley.Source, tag
 	}
 
 	value := valley.Value{
-		Name: s.Name,
-		Type: s.Node,
+		Name:         s.Name,
+		Type:         s.Node,
+		ResolvedType: s.ResolvedType,
 	}
 
 	err := g.generateConstraints(ctx, typ.Constraints, value)
@@ -165,26 +288,63 @@ func (g *Generator) generateType(config valley.Config, source valley.Source, tag
 	for _, fieldName := range fieldNames {
 		fieldConfig := typ.Fields[fieldName]
 
-		f, ok := s.Fields[fieldName]
-		if !ok {
-			return fmt.Errorf("field %q does not exist in Go source", fieldName)
+		// The field name may be a path through nested struct fields (e.g. "Address.Postcode").
+		values, err := source.FieldPath(typeName, fieldName)
+		if err != nil {
+			return err
 		}
 
-		ctx.FieldName = fieldName
-		ctx.FieldAlias, err = valley.GetFieldAliasFromTag(fieldName, tagName, f.Tag)
-		if err != nil {
-			return fmt.Errorf("failed to get field alias from struct tag: %v", err)
+		var aliases, nilChecks []string
+
+		for i, v := range values {
+			name := v.Name
+			if v.Embedded {
+				// As with encoding/json, the fields of embedded structs are promoted, so embedded
+				// structs only appear in paths if they're given an alias of their own.
+				name = ""
+			}
+
+			alias, err := valley.GetFieldAliasFromTag(name, tagName, v.Tag)
+			if err != nil {
+				return fmt.Errorf("failed to get field alias from struct tag: %v", err)
+			}
+
+			if alias != "" {
+				aliases = append(aliases, alias)
+			}
+
+			// Pointers to nested structs must not be nil for fields within them to be validated.
+			if i < len(values)-1 && v.IsPointer() {
+				nilChecks = append(nilChecks, fmt.Sprintf("%s.%s != nil", receiver, strings.Join(fieldPathNames(values[:i+1]), ".")))
+			}
 		}
 
+		ctx.FieldName = fieldName
+		ctx.FieldAlias = strings.Join(aliases, ".")
 		ctx.VarName = fmt.Sprintf("%s.%s", receiver, fieldName)
 		ctx.Path = fmt.Sprintf("\"%s\"", ctx.FieldAlias)
 		ctx.BeforeViolation = fmt.Sprintf("size := path.Write(%s)", ctx.Path)
 		ctx.AfterViolation = "path.TruncateRight(size)"
 
-		err := g.generateField(ctx, fieldConfig, f)
+		if len(aliases) == 0 {
+			// Violations on an embedded struct itself are at the path of the struct embedding it,
+			// which is the current path, without the trailing ".".
+			ctx.BeforeViolation = "path.TruncateRight(1)"
+			ctx.AfterViolation = "path.Write(\".\")"
+		}
+
+		if len(nilChecks) > 0 {
+			g.wcf("	if %s {\n", strings.Join(nilChecks, " && "))
+		}
+
+		err = g.generateField(ctx, fieldConfig, values[len(values)-1])
 		if err != nil {
 			return err
 		}
+
+		if len(nilChecks) > 0 {
+			g.wc("	}\n\n")
+		}
 	}
 
 	g.wc("	path.TruncateRight(1)\n")
@@ -192,9 +352,124 @@ func (g *Generator) generateType(config valley.Config, source valley.Source, tag
 	g.wc("	return violations\n")
 	g.wc("}\n\n")
 
+	if g.validateErr {
+		g.generateValidateErr(typ, typeName, typeParams, receiver, receiverType, methodName)
+	}
+
+	return nil
+}
+
+// checkValidateErrNames returns an error if the companion generated for a validation method (or
+// function) would have the same name as another validation method on the same type (or another
+// validation function), e.g. the companion of `Validate` and the method generated from a
+// constraints method named `ErrConstraints` are both `ValidateErr`.
+func checkValidateErrNames(config valley.Config, typeNames []string) error {
+	// Functions are all generated in the same package, whichever type they're for, so they share a
+	// set of names, keyed by the empty string.
+	names := make(map[string]map[string]bool)
+
+	for _, typeName := range typeNames {
+		for _, typeConfig := range config.Types[typeName] {
+			scope := validationNameScope(typeName, typeConfig)
+			if names[scope] == nil {
+				names[scope] = make(map[string]bool)
+			}
+
+			names[scope][validationMethodName(typeConfig)] = true
+		}
+	}
+
+	for _, typeName := range typeNames {
+		for _, typeConfig := range config.Types[typeName] {
+			methodName := validationMethodName(typeConfig)
+			if names[validationNameScope(typeName, typeConfig)][methodName+"Err"] {
+				return valley.Diagnostic{
+					Severity: valley.SeverityError,
+					Type:     typeName,
+					Message:  fmt.Sprintf("the companion of %s's %s would be named %sErr, which is already the name of another generated validation method", typeName, methodName, methodName),
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
+// validationNameScope returns the scope that the name of the validation method (or function)
+// generated for the given TypeConfig must be unique within.
+func validationNameScope(typeName string, typeConfig valley.TypeConfig) string {
+	if typeConfig.Function {
+		return ""
+	}
+
+	return typeName
+}
+
+// validationMethodName returns the name of the validation method (or function) generated for the
+// given TypeConfig.
+func validationMethodName(typeConfig valley.TypeConfig) string {
+	if typeConfig.Name == "" {
+		return "Validate"
+	}
+
+	return typeConfig.Name
+}
+
+// generateValidateErr generates a companion to the validation method (or function) with the given
+// name, which returns any violations it finds as an error, rather than a slice.
+func (g *Generator) generateValidateErr(typ valley.TypeConfig, typeName, typeParams, receiver, receiverType, methodName string) {
+	if typ.Function {
+		g.wcf("// %sErr validates the given %s, returning a *valley.ValidationError if it's invalid.\n", methodName, typeName)
+		g.wc("// This function was generated by Valley.\n")
+		g.wcf("func %sErr%s(%s %s) error {\n", methodName, typeParams, receiver, receiverType)
+		g.wcf("	return valley.NewValidationError(%s(%s, valley.NewPath()))\n", methodName, receiver)
+	} else {
+		g.wcf("// %sErr validates this %s, returning a *valley.ValidationError if it's invalid.\n", methodName, typeName)
+		g.wc("// This method was generated by Valley.\n")
+		g.wcf("func (%s %s) %sErr() error {\n", receiver, receiverType, methodName)
+		g.wcf("	return valley.NewValidationError(%s.%s(valley.NewPath()))\n", receiver, methodName)
+	}
+
+	g.wc("}\n\n")
+}
+
+// typeParamsDecl returns the declaration of the given generic struct's type parameters, with their
+// constraints (e.g. "[K comparable, V any]"), for functions that accept it.
+func (g *Generator) typeParamsDecl(source valley.Source, s valley.Struct) (string, error) {
+	named, ok := s.ResolvedType.(*types.Named)
+	if !ok || named.TypeParams().Len() != len(s.TypeParams) {
+		return "", fmt.Errorf("failed to resolve the type parameters of %q", s.Name)
+	}
+
+	params := make([]string, 0, named.TypeParams().Len())
+	for i := 0; i < named.TypeParams().Len(); i++ {
+		param := named.TypeParams().At(i)
+
+		constraint, ipts := source.TypeExpr(param.Constraint())
+		if constraint == nil {
+			return "", fmt.Errorf("failed to render the constraint of type parameter %q of %q", param.Obj().Name(), s.Name)
+		}
+
+		for _, ipt := range ipts {
+			g.ipts[ipt] = struct{}{}
+		}
+
+		params = append(params, param.Obj().Name()+" "+types.ExprString(constraint))
+	}
+
+	return "[" + strings.Join(params, ", ") + "]", nil
+}
+
+// fieldPathNames returns the names of each of the given fields.
+func fieldPathNames(values []valley.Value) []string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		names = append(names, value.Name)
+	}
+
+	return names
+}
+
 // generateField generates all of the code for a specific field.
 func (g *Generator) generateField(ctx valley.Context, fieldConfig valley.FieldConfig, value valley.Value) error {
 	err := g.generateFieldConstraints(ctx, fieldConfig, value)
@@ -246,27 +521,55 @@ func (g *Generator) generateFieldElementsConstraints(ctx valley.Context, fieldCo
 	elementCtx.PathKind = valley.PathKindElement
 
 	var elementType ast.Expr
+	var elementResolvedType types.Type
+	var isMap bool
 
-	// TODO: Can we get rid of this switch... or not return an error in the default statement?
-	// This would allow `Elements` to be used on any type, including ones Valley is unaware of.
 	switch t := value.Type.(type) {
 	case *ast.ArrayType:
 		elementType = t.Elt
-		elementCtx.Path = fmt.Sprintf("\"%s.[\" + strconv.Itoa(i) + \"]\"", elementCtx.FieldAlias)
 	case *ast.MapType:
 		elementType = t.Value
+		isMap = true
+	}
+
+	// If the type was resolved, we can also support named collection types, e.g. ones from other
+	// packages, where we don't have the AST for the collection type itself.
+	if value.ResolvedType != nil {
+		switch t := value.ResolvedType.Underlying().(type) {
+		case *types.Array:
+			elementResolvedType = t.Elem()
+		case *types.Slice:
+			elementResolvedType = t.Elem()
+		case *types.Map:
+			elementResolvedType = t.Elem()
+			isMap = true
+		}
+
+		if elementType == nil && elementResolvedType != nil {
+			// Constraints inspect the type, rather than rendering it, so it's imports aren't needed.
+			elementType, _ = ctx.Source.TypeExpr(elementResolvedType)
+		}
+	}
+
+	if elementType == nil {
+		return errors.New("config for elements applied to non-iterable type")
+	}
+
+	if isMap {
 		// TODO: Does this work well for non-string types?
 		elementCtx.Path = fmt.Sprintf("\"%s.[\" + fmt.Sprintf(\"%%v\", i) + \"]\"", elementCtx.FieldAlias)
-	default:
-		return errors.New("config for elements applied to non-iterable type")
+	} else {
+		elementCtx.Path = fmt.Sprintf("\"%s.[\" + strconv.Itoa(i) + \"]\"", elementCtx.FieldAlias)
 	}
 
 	// Set up the path writing, now we have everything we need.
 	elementCtx.BeforeViolation = fmt.Sprintf("size := path.Write(%s)", elementCtx.Path)
+	elementCtx.AfterViolation = "path.TruncateRight(size)"
 
 	elementField := valley.Value{
-		Name: value.Name,
-		Type: elementType,
+		Name:         value.Name,
+		Type:         elementType,
+		ResolvedType: elementResolvedType,
 	}
 
 	err := g.generateConstraints(elementCtx, fieldConfig.Elements, elementField)
@@ -293,9 +596,9 @@ func (g *Generator) generateFieldKeysConstraints(ctx valley.Context, fieldConfig
 	keyCtx.PathKind = valley.PathKindKey
 
 	var keyType ast.Expr
+	var keyResolvedType types.Type
+	var isMap bool
 
-	// TODO: Can we get rid of this switch... or not return an error in the default statement?
-	// This would allow `Keys` to be used on any type, including ones Valley is unaware of.
 	switch t := value.Type.(type) {
 	case *ast.ArrayType:
 		// Attempt to create type for the key...
@@ -303,20 +606,45 @@ func (g *Generator) generateFieldKeysConstraints(ctx valley.Context, fieldConfig
 			NamePos: t.Lbrack + 1, // TODO: Does this work?
 			Name:    "int",
 		}
-		keyCtx.Path = fmt.Sprintf("\"%s.[\" + strconv.Itoa(key) + \"]\"", keyCtx.FieldAlias)
 	case *ast.MapType:
 		keyType = t.Key
+		isMap = true
+	}
+
+	// If the type was resolved, we can also support named collection types, e.g. ones from other
+	// packages, where we don't have the AST for the collection type itself.
+	if value.ResolvedType != nil {
+		switch t := value.ResolvedType.Underlying().(type) {
+		case *types.Array, *types.Slice:
+			keyResolvedType = types.Typ[types.Int]
+		case *types.Map:
+			keyResolvedType = t.Key()
+			isMap = true
+		}
+
+		if keyType == nil && keyResolvedType != nil {
+			keyType, _ = ctx.Source.TypeExpr(keyResolvedType)
+		}
+	}
+
+	if keyType == nil {
+		return errors.New("config for keys applied to non-iterable type")
+	}
+
+	if isMap {
 		// TODO: Does this work well enough for non-string types?
 		keyCtx.Path = fmt.Sprintf("\"%s.[\" + fmt.Sprintf(\"%%v\", key) + \"]\"", keyCtx.FieldAlias)
-	default:
-		return errors.New("config for keys applied to non-iterable type")
+	} else {
+		keyCtx.Path = fmt.Sprintf("\"%s.[\" + strconv.Itoa(key) + \"]\"", keyCtx.FieldAlias)
 	}
 
 	// Set up the path writing, now we have everything we need.
 	keyCtx.BeforeViolation = fmt.Sprintf("size := path.Write(%s)", keyCtx.Path)
+	keyCtx.AfterViolation = "path.TruncateRight(size)"
 	keyField := valley.Value{
-		Name: value.Name,
-		Type: keyType,
+		Name:         value.Name,
+		Type:         keyType,
+		ResolvedType: keyResolvedType,
 	}
 
 	err := g.generateConstraints(keyCtx, fieldConfig.Keys, keyField)
@@ -333,6 +661,22 @@ func (g *Generator) generateFieldKeysConstraints(ctx valley.Context, fieldConfig
 func (g *Generator) generateConstraints(ctx valley.Context, constraintConfigs []valley.ConstraintConfig, value valley.Value) error {
 	var predicate ast.Expr
 
+	ctx.Optional = constraints.IsOptional(constraintConfigs)
+
+	if constraints.IsMarkedOptional(constraintConfigs) {
+		// Every constraint is skipped if the value is empty, so there's no need for each constraint
+		// to check that individually too.
+		ctx.Optional = false
+
+		emptinessPredicate, imports := constraints.GenerateEmptinessPredicate(ctx.VarName, value.Type, value.ResolvedType)
+		for _, ipt := range imports {
+			g.ipts[ipt] = struct{}{}
+		}
+
+		g.wcf("if !(%s) {\n", emptinessPredicate)
+		defer g.wc("}\n")
+	}
+
 	for i, constraintConfig := range constraintConfigs {
 		if constraintConfig.Predicate != nil && constraintConfig.Predicate != predicate {
 			// If we have a predicate, keep it around so we can re-use the surrounding if statement.
@@ -382,15 +726,40 @@ func (g *Generator) generateConstraint(ctx valley.Context, constraintConfig vall
 
 	ctx.Constraint = constraintConfig.Name
 	ctx.ConstraintNum = g.constraintNum
+	ctx.Message = constraintConfig.Message
+	ctx.Code = constraintConfig.Code
+	ctx.ResolvedType = value.ResolvedType
+
+	diagnostic := valley.Diagnostic{
+		File:       pos.Filename,
+		Line:       pos.Line,
+		Column:     pos.Column,
+		Constraint: constraintConfig.Name,
+		Type:       ctx.TypeName,
+		Field:      ctx.FieldName,
+	}
+
+	// Optional applies to every constraint on a value, so it can't only apply some of the time.
+	if constraintConfig.Predicate != nil && constraints.IsMarkedOptional([]valley.ConstraintConfig{{Name: constraintConfig.Name}}) {
+		diagnostic.Severity = valley.SeverityError
+		diagnostic.Message = fmt.Sprintf("failed to generate code for %s's %q constraint: cannot be used inside When, as it applies to every constraint on the value", selector, constraintConfig.Name)
+		return diagnostic
+	}
 
 	output, err := constraint(ctx, value.Type, constraintConfig.Opts)
 	switch {
+	case errors.Is(err, constraints.ErrTypeWarning) && g.strict:
+		diagnostic.Severity = valley.SeverityError
+		diagnostic.Message = fmt.Sprintf("generating code for %s's %q constraint: %v", selector, constraintConfig.Name, err)
+		return diagnostic
 	case errors.Is(err, constraints.ErrTypeWarning):
-		fmt.Printf("valley: warning generating code for %s's %q constraint on line %d, col %d: %v\n",
-			selector, constraintConfig.Name, pos.Line, pos.Column, err)
+		diagnostic.Severity = valley.SeverityWarning
+		diagnostic.Message = fmt.Sprintf("generating code for %s's %q constraint: %v", selector, constraintConfig.Name, err)
+		g.reporter.Report(diagnostic)
 	case err != nil:
-		return fmt.Errorf("failed to generate code for %s's %q constraint on line %d, col %d: %v",
-			selector, constraintConfig.Name, pos.Line, pos.Column, err)
+		diagnostic.Severity = valley.SeverityError
+		diagnostic.Message = fmt.Sprintf("failed to generate code for %s's %q constraint: %v", selector, constraintConfig.Name, err)
+		return diagnostic
 	}
 
 	for _, ipt := range output.Imports {
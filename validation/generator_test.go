@@ -4,7 +4,6 @@ import (
 	"flag"
 	"fmt"
 	"go/format"
-	"go/parser"
 	"go/token"
 	"io/ioutil"
 	"testing"
@@ -41,29 +40,78 @@ func TestNewGenerator(t *testing.T) {
 	t.Run("should initialise the vars map", func(t *testing.T) {
 		assert.NotNil(t, NewGenerator(constraints.BuiltIn).vars)
 	})
+
+	t.Run("should discard diagnostics by default", func(t *testing.T) {
+		assert.Equal(t, valley.DiscardReporter, NewGenerator(constraints.BuiltIn).reporter)
+	})
+
+	t.Run("should apply the given options", func(t *testing.T) {
+		reporter := valley.NewTextReporter(ioutil.Discard)
+		generator := NewGenerator(constraints.BuiltIn, WithReporter(reporter))
+
+		assert.Equal(t, reporter, generator.reporter)
+	})
 }
 
 func TestGenerator_Generate(t *testing.T) {
 	tt := []struct {
-		name string
-		desc string
+		name             string
+		desc             string
+		opts             []Option
+		tagName          string
+		validatorTagName string
 	}{
 		{name: "td01", desc: "should successfully generate code given valid input"},
+		{name: "td02", desc: "should generate code for types declared in other files in the same package"},
+		{name: "td03", desc: "should generate code based on the underlying types of named types"},
+		{name: "td04", desc: "should generate a validation method for each constraints method"},
+		{name: "td05", desc: "should generate validation functions for constraints functions"},
+		{name: "td06", desc: "should still generate code for constraints that produce warnings"},
+		{name: "td07", desc: "should skip optional constraints on empty values, unless they're required"},
+		{name: "td08", desc: "should generate code for constraints declared in struct tags", tagName: "valley"},
+		{name: "td09", desc: "should generate code for constraints declared in validator struct tags", validatorTagName: "validate"},
+		{name: "td10", desc: "should generate nil-safe code for fields of nested structs"},
+		{name: "td11", desc: "should generate code for promoted fields, and validate embedded structs", opts: []Option{WithValidEmbedded(true)}},
+		{name: "td12", desc: "should error if Valid is used on an anonymous inline struct"},
+		{name: "td13", desc: "should generate code to validate nested types that have validation methods", opts: []Option{WithAutoValid(true)}},
+		{name: "td14", desc: "should generate code for generic types"},
+		{name: "td15", desc: "should error if Valid is used on a type parameter without a validation method"},
+		{name: "td16", desc: "should generate code that validates interface fields at runtime"},
+		{name: "td17", desc: "should generate companions that return violations as errors", opts: []Option{WithValidateErr(true)}},
+		{name: "td18", desc: "should generate code using the messages and codes given by WithMessage and WithCode"},
+		{name: "td19", desc: "should error if WithMessage is used on a constraint that doesn't produce violations"},
+		{name: "td20", desc: "should error if a companion would have the same name as another validation method", opts: []Option{WithValidateErr(true)}},
+		{name: "td21", desc: "should skip optional constraints on empty values that are only conditionally required"},
+		{name: "td22", desc: "should error if Optional is used inside When"},
+		{name: "td23", desc: "should generate functions, and their companions, for generic types from constraints functions", opts: []Option{WithValidateErr(true)}},
 	}
 
 	for _, tc := range tt {
 		inFile := fmt.Sprintf("./testdata/%s/testdata.go", tc.name)
 		outFile := fmt.Sprintf("./testdata/%s/testdata.txt", tc.name)
 
-		fileSet := token.NewFileSet()
-		file, err := parser.ParseFile(fileSet, inFile, nil, 0)
+		src, err := source.Read(token.NewFileSet(), inFile)
 		require.NoError(t, err)
-
-		src := source.Read(fileSet, file, inFile)
 		cfg, err := config.BuildFromSource(src)
 		require.NoError(t, err)
 
-		generator := NewGenerator(constraints.BuiltIn)
+		if tc.tagName != "" {
+			tagsCfg, err := config.BuildFromTags(src, tc.tagName)
+			require.NoError(t, err)
+
+			cfg, err = config.Merge(cfg, tagsCfg)
+			require.NoError(t, err)
+		}
+
+		if tc.validatorTagName != "" {
+			validatorCfg, err := config.BuildFromValidatorTags(src, tc.validatorTagName)
+			require.NoError(t, err)
+
+			cfg, err = config.Merge(cfg, validatorCfg)
+			require.NoError(t, err)
+		}
+
+		generator := NewGenerator(constraints.BuiltIn, tc.opts...)
 
 		bs, err := generator.Generate(cfg, src, "valley")
 
@@ -94,3 +142,44 @@ func TestGenerator_Generate(t *testing.T) {
 		assert.Equal(t, string(bs), actual)
 	}
 }
+
+func TestGenerator_GenerateWarnings(t *testing.T) {
+	src, err := source.Read(token.NewFileSet(), "./testdata/td06/testdata.go")
+	require.NoError(t, err)
+	cfg, err := config.BuildFromSource(src)
+	require.NoError(t, err)
+
+	t.Run("should report warnings", func(t *testing.T) {
+		reporter := &recordingReporter{}
+
+		_, err := NewGenerator(constraints.BuiltIn, WithReporter(reporter)).Generate(cfg, src, "valley")
+		require.NoError(t, err)
+		require.Len(t, reporter.diagnostics, 1)
+
+		diagnostic := reporter.diagnostics[0]
+		assert.Equal(t, valley.SeverityWarning, diagnostic.Severity)
+		assert.Equal(t, "github.com/seeruk/valley/validation/constraints.MaxLength", diagnostic.Constraint)
+		assert.Equal(t, "Subject", diagnostic.Type)
+		assert.Equal(t, "Count", diagnostic.Field)
+		assert.Equal(t, 16, diagnostic.Line)
+	})
+
+	t.Run("should error instead of reporting warnings in strict mode", func(t *testing.T) {
+		reporter := &recordingReporter{}
+
+		_, err := NewGenerator(constraints.BuiltIn, WithReporter(reporter), WithStrict(true)).Generate(cfg, src, "valley")
+		require.Error(t, err)
+		require.IsType(t, valley.Diagnostic{}, err)
+		assert.Equal(t, valley.SeverityError, err.(valley.Diagnostic).Severity)
+		assert.Empty(t, reporter.diagnostics)
+	})
+}
+
+// recordingReporter is a valley.Reporter that keeps hold of the Diagnostics it's given.
+type recordingReporter struct {
+	diagnostics []valley.Diagnostic
+}
+
+func (r *recordingReporter) Report(diagnostic valley.Diagnostic) {
+	r.diagnostics = append(r.diagnostics, diagnostic)
+}
@@ -0,0 +1,13 @@
+package td02
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Constraints is a valley constraints method for a type declared in another file, used for testing
+// code generation functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.SomeText).Constraints(constraints.Required())
+	t.Field(s.SomeInt).Constraints(constraints.Min(1))
+}
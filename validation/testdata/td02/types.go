@@ -0,0 +1,13 @@
+package td02
+
+// Subject is a type used for testing code generation functionality.
+type Subject struct {
+	SomeText string `valley:"some_text"`
+	SomeInt  int    `valley:"some_int"`
+}
+
+// String returns a string representation of this Subject, it's here to ensure the receiver name of
+// the constraints method is used, not the first method found.
+func (sub Subject) String() string {
+	return sub.SomeText
+}
@@ -0,0 +1,40 @@
+package td03
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Email is a named string type, used to test that underlying types are resolved.
+type Email string
+
+// IDs is a named slice type, used to test that underlying types are resolved.
+type IDs []string
+
+// Subject is a type used for testing code generation functionality.
+type Subject struct {
+	Email   Email         `valley:"email"`
+	IDs     IDs           `valley:"ids"`
+	Timeout time.Duration `valley:"timeout"`
+	Query   url.Values    `valley:"query"`
+	Active  bool          `valley:"active"`
+}
+
+// Constraints is a valley constraints method used for testing code generation functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Constraints(constraints.MutuallyExclusive(s.Email, s.IDs, s.Timeout, s.Active))
+
+	t.Field(s.Email).
+		Constraints(constraints.Required(), constraints.MaxLength(255), constraints.RegexpString("@"))
+	t.Field(s.IDs).
+		Constraints(constraints.Required()).
+		Elements(constraints.Required(), constraints.Length(36))
+	t.Field(s.Timeout).
+		Constraints(constraints.Required(), constraints.Min(1))
+	t.Field(s.Query).
+		Elements(constraints.MinLength(1)).
+		Keys(constraints.MinLength(1))
+}
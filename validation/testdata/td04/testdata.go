@@ -0,0 +1,45 @@
+package td04
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation functionality.
+type Subject struct {
+	ID     string         `valley:"id"`
+	Name   string         `valley:"name"`
+	Nested *NestedSubject `valley:"nested"`
+}
+
+// Constraints is a valley constraints method used for testing code generation functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.MaxLength(64))
+}
+
+// CreateConstraints is a valley constraints method used for testing code generation functionality.
+func (s Subject) CreateConstraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+	t.Field(s.Nested).Constraints(constraints.Valid("ValidateCreate"))
+}
+
+// UpdateConstraints is a valley constraints method used for testing code generation functionality.
+func (sub Subject) UpdateConstraints(t valley.Type) {
+	t.Field(sub.ID).Constraints(constraints.Required())
+	t.Field(sub.Nested).Constraints(constraints.Valid("ValidateUpdate"))
+}
+
+// NestedSubject is a type used for testing code generation functionality.
+type NestedSubject struct {
+	Text string `valley:"text"`
+}
+
+// CreateConstraints is a valley constraints method used for testing code generation functionality.
+func (n NestedSubject) CreateConstraints(t valley.Type) {
+	t.Field(n.Text).Constraints(constraints.Required())
+}
+
+// UpdateConstraints is a valley constraints method used for testing code generation functionality.
+func (n NestedSubject) UpdateConstraints(t valley.Type) {
+	t.Field(n.Text).Constraints(constraints.MaxLength(32))
+}
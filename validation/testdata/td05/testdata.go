@@ -0,0 +1,35 @@
+package td05
+
+import (
+	"net/url"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation functionality.
+type Subject struct {
+	Name string `valley:"name"`
+}
+
+// SubjectConstraints is a valley constraints function used for testing code generation functionality.
+func SubjectConstraints(s Subject, t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+}
+
+// URLConstraints is a valley constraints function used for testing code generation functionality.
+func URLConstraints(u url.URL, t valley.Type) {
+	t.Field(u.Scheme).Constraints(constraints.OneOf("http", "https"))
+	t.Field(u.Host).Constraints(constraints.Required())
+	t.Field(u.User).Constraints(constraints.NotNil())
+}
+
+// Options is a type used for testing code generation functionality.
+type Options struct {
+	Limit int `valley:"limit"`
+}
+
+// OptionsConstraints is a valley constraints function used for testing code generation functionality.
+func OptionsConstraints(o *Options, t valley.Type) {
+	t.Field(o.Limit).Constraints(constraints.Min(1))
+}
@@ -0,0 +1,17 @@
+package td06
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation functionality.
+type Subject struct {
+	Count int `valley:"count"`
+}
+
+// Constraints is a valley constraints method used for testing code generation functionality. The
+// MaxLength constraint isn't supported on ints, so it produces a warning.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Count).Constraints(constraints.MaxLength(3))
+}
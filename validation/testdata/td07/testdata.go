@@ -0,0 +1,29 @@
+package td07
+
+import (
+	"strings"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation functionality.
+type Subject struct {
+	Optional  string   `valley:"optional"`
+	Required  string   `valley:"required"`
+	NotEmpty  int      `valley:"not_empty"`
+	Predicate *string  `valley:"predicate"`
+	Elements  []string `valley:"elements"`
+}
+
+// Constraints is a valley constraints method used for testing code generation functionality.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Optional).Constraints(constraints.MaxLength(8))
+	t.Field(s.Required).Constraints(constraints.Required(), constraints.MaxLength(8))
+	t.Field(s.NotEmpty).Constraints(constraints.NotEmpty(), constraints.Min(1))
+	t.Field(s.Predicate).Constraints(
+		constraints.Optional(),
+		constraints.Predicate(!strings.HasPrefix(*s.Predicate, "a"), "value must start with 'a'"),
+	)
+	t.Field(s.Elements).Elements(constraints.MinLength(2))
+}
@@ -0,0 +1,34 @@
+package td08
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation, with constraints declared in struct tags.
+type Subject struct {
+	Email  string   `valley:"email,required,max_length=255"`
+	Role   string   `valley:"role,one_of=admin user"`
+	Age    int      `valley:"age,min=18"`
+	Nick   *string  `valley:"nick,regexp=^[a-z]+$"`
+	Parent *Subject `valley:"parent,valid"`
+	Meta   struct {
+		Owner string `valley:"owner,required"`
+		Extra *struct {
+			Note string `valley:"note,max_length=64"`
+		} `valley:"extra"`
+	} `valley:"meta"`
+}
+
+// Mixed is a type used for testing code generation, with constraints declared in both struct tags
+// and a constraints method.
+type Mixed struct {
+	Name string `valley:"name,required"`
+	Note string `valley:"note"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (m Mixed) Constraints(t valley.Type) {
+	t.Field(m.Name).Constraints(constraints.MaxLength(10))
+	t.Field(m.Note).Constraints(constraints.MinLength(2))
+}
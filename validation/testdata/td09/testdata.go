@@ -0,0 +1,14 @@
+package td09
+
+// Subject is a type used for testing code generation, with constraints declared in validator
+// struct tags.
+type Subject struct {
+	Email  string            `valley:"email" validate:"required,max=255,email"`
+	ID     string            `valley:"id" validate:"omitempty,uuid"`
+	Age    *int              `valley:"age" validate:"gte=18"`
+	Tags   []string          `valley:"tags" validate:"max=10,dive,required"`
+	Labels map[string]string `valley:"labels" validate:"dive,keys,min=2,endkeys,oneof=a b"`
+	Meta   *struct {
+		Owner string `valley:"owner" validate:"required"`
+	} `valley:"meta"`
+}
@@ -0,0 +1,37 @@
+package td10
+
+import (
+	"net/url"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation for nested fields.
+type Subject struct {
+	Address  Address  `valley:"address"`
+	Previous *Address `valley:"previous"`
+	Link     *url.URL `valley:"link"`
+	Meta     struct {
+		Owner  string `valley:"owner"`
+		Parent *struct {
+			Name string `valley:"name"`
+		} `valley:"parent"`
+	} `valley:"meta"`
+}
+
+// Address is a type used for testing code generation for nested fields.
+type Address struct {
+	Postcode string   `valley:"postcode"`
+	Lines    []string `valley:"lines"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Address.Postcode).Constraints(constraints.Required())
+	t.Field(s.Address.Lines).Elements(constraints.MaxLength(64))
+	t.Field(s.Previous.Postcode).Constraints(constraints.MinLength(5))
+	t.Field(s.Link.Host).Constraints(constraints.Required())
+	t.Field(s.Meta.Owner).Constraints(constraints.Required())
+	t.Field(s.Meta.Parent.Name).Constraints(constraints.MaxLength(10))
+}
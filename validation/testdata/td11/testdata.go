@@ -0,0 +1,42 @@
+package td11
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation for embedded structs.
+type Subject struct {
+	Timestamps
+	*Audit `valley:"audit"`
+
+	Name string `valley:"name"`
+}
+
+// Timestamps is a type used for testing code generation for embedded structs.
+type Timestamps struct {
+	CreatedAt string `valley:"created_at"`
+	UpdatedAt string `valley:"updated_at"`
+}
+
+// Audit is a type used for testing code generation for embedded structs.
+type Audit struct {
+	Owner string `valley:"owner"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+	t.Field(s.CreatedAt).Constraints(constraints.Required())
+	t.Field(s.Owner).Constraints(constraints.MaxLength(32))
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (t Timestamps) Constraints(c valley.Type) {
+	c.Field(t.UpdatedAt).Constraints(constraints.Required())
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (a Audit) Constraints(t valley.Type) {
+	t.Field(a.Owner).Constraints(constraints.Required())
+}
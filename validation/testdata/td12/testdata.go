@@ -0,0 +1,18 @@
+package td12
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation for anonymous inline structs.
+type Subject struct {
+	Meta struct {
+		Owner string `valley:"owner"`
+	} `valley:"meta"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Meta).Constraints(constraints.Valid())
+}
@@ -0,0 +1,26 @@
+package td13
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Billing is a type used for testing code generation that automatically validates nested types,
+// which has a constraints method declared in a different file to the type being validated.
+type Billing struct {
+	Reference string `valley:"reference"`
+}
+
+// Rules is a valley constraints method used for testing code generation.
+func (b Billing) Rules(t valley.Type) {
+	t.Field(b.Reference).Constraints(constraints.Required())
+}
+
+// Notes is a type used for testing code generation that automatically validates nested types,
+// which has a method named Rules that isn't a constraints method.
+type Notes struct {
+	Text string `valley:"text"`
+}
+
+// Rules is a method used for testing code generation, which isn't a constraints method.
+func (n Notes) Rules(t string) {}
@@ -0,0 +1,82 @@
+package td13
+
+import (
+	"context"
+	"time"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation that automatically validates nested types.
+type Subject struct {
+	Name      string             `valley:"name"`
+	Address   Address            `valley:"address"`
+	Previous  *Address           `valley:"previous"`
+	History   []Address          `valley:"history"`
+	Others    []*Address         `valley:"others"`
+	ByName    map[string]Address `valley:"by_name"`
+	Named     Addresses          `valley:"named"`
+	Explicit  Address            `valley:"explicit"`
+	CreatedAt time.Time          `valley:"created_at"`
+	Contact   Contact            `valley:"contact"`
+	Session   Session            `valley:"session"`
+	Billing   Billing            `valley:"billing"`
+	Notes     Notes              `valley:"notes"`
+	Meta      struct {
+		Owner string `valley:"owner"`
+	} `valley:"meta"`
+}
+
+// Addresses is a type used for testing code generation that automatically validates nested types.
+type Addresses []Address
+
+// Address is a type used for testing code generation that automatically validates nested types.
+type Address struct {
+	Postcode string `valley:"postcode"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+	t.Field(s.Explicit).Constraints(constraints.Valid())
+}
+
+// CreateConstraints is a valley constraints method used for testing code generation, which doesn't
+// validate nested types, as they don't have a validation method of the same name.
+func (s Subject) CreateConstraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+}
+
+// Rules is a valley constraints method used for testing code generation, which validates nested
+// types that have a constraints method named Rules, declared in another file.
+func (s Subject) Rules(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (a Address) Constraints(t valley.Type) {
+	t.Field(a.Postcode).Constraints(constraints.Required())
+}
+
+// Contact is a type used for testing code generation that automatically validates nested types,
+// which has a hand-written validation method.
+type Contact struct {
+	Email string `valley:"email"`
+}
+
+// Validate is a hand-written validation method used for testing code generation.
+func (c Contact) Validate(path *valley.Path) []valley.ConstraintViolation {
+	return nil
+}
+
+// Session is a type used for testing code generation that automatically validates nested types,
+// which has a method named Validate that isn't a validation method.
+type Session struct {
+	ID string `valley:"id"`
+}
+
+// Validate is a method used for testing code generation, which isn't a validation method.
+func (s Session) Validate(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package td14
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Validatable is an interface used for testing code generation for generic types.
+type Validatable interface {
+	Validate(path *valley.Path) []valley.ConstraintViolation
+}
+
+// Page is a type used for testing code generation for generic types.
+type Page[T Validatable] struct {
+	Items []T `valley:"items"`
+	First T   `valley:"first"`
+	Total int `valley:"total"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (p Page[T]) Constraints(t valley.Type) {
+	t.Field(p.Items).Constraints(constraints.MaxLength(100)).Elements(constraints.Valid())
+	t.Field(p.First).Constraints(constraints.Valid())
+	t.Field(p.Total).Constraints(constraints.Min(0))
+}
+
+// Envelope is a type used for testing code generation for generic types.
+type Envelope[K comparable, V any] struct {
+	Data map[K]V `valley:"data"`
+	Meta string  `valley:"meta"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (e *Envelope[K, V]) Constraints(t valley.Type) {
+	t.Field(e.Data).Constraints(constraints.Required())
+	t.Field(e.Meta).Constraints(constraints.MaxLength(64))
+}
@@ -0,0 +1,16 @@
+package td15
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Box is a type used for testing code generation for generic types.
+type Box[T any] struct {
+	Value T `valley:"value"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (b Box[T]) Constraints(t valley.Type) {
+	t.Field(b.Value).Constraints(constraints.Valid())
+}
@@ -0,0 +1,51 @@
+package td16
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Request is a type used for testing code generation for interface fields.
+type Request struct {
+	Body     interface{}        `valley:"body"`
+	Payload  valley.Validator   `valley:"payload"`
+	Any      any                `valley:"any"`
+	Items    []interface{}      `valley:"items"`
+	Versions map[string]any     `valley:"versions"`
+	Concrete Body               `valley:"concrete"`
+	Pointer  *Body              `valley:"pointer"`
+	Nested   map[string]*Body   `valley:"nested"`
+	Created  valley.Validator   `valley:"created"`
+	Mixed    []valley.Validator `valley:"mixed"`
+	Drafts   []interface{}      `valley:"drafts"`
+}
+
+// Body is a type used for testing code generation for interface fields.
+type Body struct {
+	Name string `valley:"name"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (r Request) Constraints(t valley.Type) {
+	t.Field(r.Body).Constraints(constraints.Valid())
+	t.Field(r.Payload).Constraints(constraints.Required(), constraints.Valid())
+	t.Field(r.Any).Constraints(constraints.Valid())
+	t.Field(r.Items).Elements(constraints.Valid())
+	t.Field(r.Versions).Elements(constraints.Valid())
+	t.Field(r.Concrete).Constraints(constraints.Valid())
+	t.Field(r.Pointer).Constraints(constraints.Valid())
+	t.Field(r.Nested).Elements(constraints.Valid())
+	t.Field(r.Created).Constraints(constraints.Valid("ValidateCreate"))
+	t.Field(r.Mixed).Elements(constraints.Valid())
+	t.Field(r.Drafts).Elements(constraints.Valid("ValidateCreate"))
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (b Body) Constraints(t valley.Type) {
+	t.Field(b.Name).Constraints(constraints.Required())
+}
+
+// CreateConstraints is a valley constraints method used for testing code generation.
+func (b Body) CreateConstraints(t valley.Type) {
+	t.Field(b.Name).Constraints(constraints.Required())
+}
@@ -0,0 +1,38 @@
+package td17
+
+import (
+	"image"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing generating methods that return violations as errors.
+type Subject struct {
+	Name string `valley:"name"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.Required())
+}
+
+// CreateConstraints is a valley constraints method used for testing code generation.
+func (s Subject) CreateConstraints(t valley.Type) {
+	t.Field(s.Name).Constraints(constraints.MaxLength(10))
+}
+
+// List is a type used for testing generating methods that return violations as errors.
+type List[T any] struct {
+	Items []T `valley:"items"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (l List[T]) Constraints(t valley.Type) {
+	t.Field(l.Items).Constraints(constraints.Required())
+}
+
+// PointConstraints is a valley constraints function used for testing code generation.
+func PointConstraints(p image.Point, t valley.Type) {
+	t.Field(p.X).Constraints(constraints.Min(0))
+}
@@ -0,0 +1,23 @@
+package td18
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation.
+type Subject struct {
+	Age   int     `valley:"age"`
+	Email *string `valley:"email"`
+	Phone *string `valley:"phone"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Constraints(constraints.WithMessage(constraints.MutuallyExclusive(s.Email, s.Phone), "give either an email address or a phone number"))
+
+	t.Field(s.Age).Constraints(
+		constraints.WithCode(constraints.WithMessage(constraints.Min(18), "you must be an adult"), "adult_required"),
+		constraints.Max(150),
+	)
+}
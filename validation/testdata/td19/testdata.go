@@ -0,0 +1,16 @@
+package td19
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation.
+type Subject struct {
+	Age int `valley:"age"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Age).Constraints(constraints.WithMessage(constraints.Optional(), "age is optional"))
+}
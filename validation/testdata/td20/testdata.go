@@ -0,0 +1,22 @@
+package td20
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation.
+type Subject struct {
+	Text string `valley:"text"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Text).Constraints(constraints.Required())
+}
+
+// ErrConstraints is a valley constraints method used for testing code generation, which generates
+// a method with the same name as the companion of Validate.
+func (s Subject) ErrConstraints(t valley.Type) {
+	t.Field(s.Text).Constraints(constraints.MaxLength(8))
+}
@@ -0,0 +1,18 @@
+package td22
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Subject is a type used for testing code generation.
+type Subject struct {
+	Draft bool   `valley:"draft"`
+	Title string `valley:"title"`
+}
+
+// Constraints is a valley constraints method used for testing code generation.
+func (s Subject) Constraints(t valley.Type) {
+	t.Field(s.Title).Constraints(constraints.Predicate(s.Title != "untitled", "must be titled"))
+	t.When(s.Draft).Field(s.Title).Constraints(constraints.Optional())
+}
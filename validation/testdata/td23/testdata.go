@@ -0,0 +1,32 @@
+package td23
+
+import (
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// Page is a type used for testing code generation for constraints functions on generic types.
+type Page[T any] struct {
+	Items []T `valley:"items"`
+	Total int `valley:"total"`
+}
+
+// PageConstraints is a valley constraints function used for testing code generation, which accepts
+// a pointer to a generic type.
+func PageConstraints[T any](p *Page[T], t valley.Type) {
+	t.Field(p.Items).Constraints(constraints.MaxLength(100))
+	t.Field(p.Total).Constraints(constraints.Min(0))
+}
+
+// Pair is a type used for testing code generation for constraints functions on generic types.
+type Pair[K comparable, V any] struct {
+	Key   K      `valley:"key"`
+	Value V      `valley:"value"`
+	Label string `valley:"label"`
+}
+
+// PairConstraints is a valley constraints function used for testing code generation, which accepts
+// a generic type with many type parameters.
+func PairConstraints[K comparable, V any](p Pair[K, V], t valley.Type) {
+	t.Field(p.Label).Constraints(constraints.Required())
+}
@@ -0,0 +1,196 @@
+package validation
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"github.com/seeruk/valley"
+	"github.com/seeruk/valley/config"
+	"github.com/seeruk/valley/validation/constraints"
+)
+
+// autoValidConfig returns a copy of the given TypeConfig, with the Valid constraint added to each
+// field on the type whose type has a validation method of the same name, and to the elements of
+// each slice, array, or map field whose element type does, unless they've already been given it.
+// If embeddedOnly is true, only embedded struct fields are given the Valid constraint.
+//
+// Validation methods are either generated for types in the given Config, declared (or generated
+// from constraints methods) elsewhere in the package, or found using type information for types
+// from other packages.
+func autoValidConfig(config valley.Config, source valley.Source, typeName string, typ valley.TypeConfig, embeddedOnly bool) valley.TypeConfig {
+	s, ok := source.Structs[typeName]
+	if !ok || typ.Function {
+		return typ
+	}
+
+	methodName := typ.Name
+	if methodName == "" {
+		methodName = "Validate"
+	}
+
+	fields := make(map[string]valley.FieldConfig, len(typ.Fields))
+	for fieldName, fieldConfig := range typ.Fields {
+		fields[fieldName] = fieldConfig
+	}
+
+	for _, fieldName := range s.FieldNames {
+		field := s.Fields[fieldName]
+		if embeddedOnly && !field.Embedded {
+			continue
+		}
+
+		fieldConfig := fields[fieldName]
+
+		switch {
+		case hasValidationMethod(config, field, methodName):
+			if constraints.HasValid(fieldConfig.Constraints) {
+				continue
+			}
+
+			// Copied, so that the given TypeConfig isn't modified.
+			fieldConfig.Constraints = appendValid(fieldConfig.Constraints, methodName)
+		case !embeddedOnly:
+			element, ok := elementValue(source, field)
+			if !ok || !hasValidationMethod(config, element, methodName) || constraints.HasValid(fieldConfig.Elements) {
+				continue
+			}
+
+			fieldConfig.Elements = appendValid(fieldConfig.Elements, methodName)
+		default:
+			continue
+		}
+
+		fields[fieldName] = fieldConfig
+	}
+
+	typ.Fields = fields
+
+	return typ
+}
+
+// appendValid returns a copy of the given constraints, with the Valid constraint for the validation
+// method with the given name added.
+func appendValid(constraintConfigs []valley.ConstraintConfig, methodName string) []valley.ConstraintConfig {
+	validConfig := valley.ConstraintConfig{
+		Name: "github.com/seeruk/valley/validation/constraints.Valid",
+	}
+
+	if methodName != "Validate" {
+		validConfig.Opts = []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(methodName)}}
+	}
+
+	return append(append([]valley.ConstraintConfig{}, constraintConfigs...), validConfig)
+}
+
+// elementValue returns a Value representing the elements of the given slice, array, or map value.
+// If the value isn't one of those, false is returned.
+func elementValue(source valley.Source, value valley.Value) (valley.Value, bool) {
+	element := valley.Value{
+		Name: value.Name,
+	}
+
+	switch t := value.Type.(type) {
+	case *ast.ArrayType:
+		element.Type = t.Elt
+	case *ast.MapType:
+		element.Type = t.Value
+	}
+
+	if value.ResolvedType != nil {
+		switch t := value.ResolvedType.Underlying().(type) {
+		case *types.Array:
+			element.ResolvedType = t.Elem()
+		case *types.Slice:
+			element.ResolvedType = t.Elem()
+		case *types.Map:
+			element.ResolvedType = t.Elem()
+		}
+	}
+
+	if element.Type == nil && element.ResolvedType != nil {
+		element.Type, _ = source.TypeExpr(element.ResolvedType)
+	}
+
+	return element, element.Type != nil
+}
+
+// hasValidationMethod returns true if the type of the given value has a validation method with the
+// given name, either because it will be generated using the given Config, because it has a
+// constraints method elsewhere in the package, or because it already exists (e.g. it's written by
+// hand, or on a type from another package). Methods are looked up using type information. Anonymous inline structs never have one.
+func hasValidationMethod(config valley.Config, value valley.Value, methodName string) bool {
+	expr := value.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	// Instances of generic types are configured by the name of the generic type.
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		expr = t.X
+	case *ast.IndexListExpr:
+		expr = t.X
+	}
+
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+	default:
+		return false
+	}
+
+	typeName := types.ExprString(expr)
+
+	for _, typeConfig := range config.Types[typeName] {
+		if !typeConfig.Function && (typeConfig.Name == methodName || typeConfig.Name == "" && methodName == "Validate") {
+			return true
+		}
+	}
+
+	// Generated code isn't type checked, so validation methods that will be generated from
+	// constraints methods elsewhere in the package are looked for too.
+	return value.ResolvedType != nil && (hasConstraintsMethod(value.ResolvedType, methodName) ||
+		constraints.HasValidationMethod(value.ResolvedType, methodName))
+}
+
+// hasConstraintsMethod returns true if the given type has a constraints method (i.e. one that
+// accepts a valley.Type, and returns nothing) that a validation method with the given name is
+// generated from.
+func hasConstraintsMethod(typ types.Type, methodName string) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	// Methods are declared on the generic type, rather than it's instances.
+	named = named.Origin()
+
+	for i := 0; i < named.NumMethods(); i++ {
+		method := named.Method(i)
+		if config.ValidateMethodName(method.Name()) != methodName {
+			continue
+		}
+
+		signature, ok := method.Type().(*types.Signature)
+		if ok && signature.Params().Len() == 1 && signature.Results().Len() == 0 &&
+			isValleyType(signature.Params().At(0).Type(), "Type") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValleyType returns true if the given type is the named type with the given name in Valley's
+// package.
+func isValleyType(typ types.Type, name string) bool {
+	named, ok := typ.(*types.Named)
+
+	return ok && named.Obj().Name() == name && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == valley.ImportPath
+}
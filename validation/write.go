@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/format"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,13 +19,19 @@ func FormatAndWrite(bs []byte, destPath string) error {
 
 	defer destFile.Close()
 
+	return FormatAndWriteTo(bs, destFile)
+}
+
+// FormatAndWriteTo formats some generated Go source code (the input bs), and writes it to the given
+// writer (e.g. stdout).
+func FormatAndWriteTo(bs []byte, w io.Writer) error {
 	formatted, err := format.Source(bs)
 	if err != nil {
-		fmt.Println(string(bs))
+		fmt.Fprintln(os.Stderr, string(bs))
 		return fmt.Errorf("failed to format generated source: %v", err)
 	}
 
-	_, err = destFile.Write(formatted)
+	_, err = w.Write(formatted)
 	if err != nil {
 		return fmt.Errorf("failed to write generated source to source: %v", err)
 	}
@@ -32,6 +39,23 @@ func FormatAndWrite(bs []byte, destPath string) error {
 	return nil
 }
 
+// Check formats some generated Go source code (the input bs), and compares it to the contents of
+// the existing file at destPath. If they differ, a unified diff between them is returned, otherwise
+// the returned diff is empty. A destination file that doesn't exist yet is treated as empty.
+func Check(bs []byte, destPath string) (string, error) {
+	formatted, err := format.Source(bs)
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated source: %v", err)
+	}
+
+	existing, err := readFile(destPath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read destination source: %s: %q", destPath, err)
+	}
+
+	return Diff(destPath, destPath+" (generated)", existing, formatted), nil
+}
+
 // FindDestination attempts to find a sensible destination file path. The file path is absolute.
 func FindDestination(srcPath string) string {
 	destName := strings.TrimSuffix(srcPath, filepath.Ext(srcPath))
@@ -46,3 +70,6 @@ func FindDestination(srcPath string) string {
 var createFile = func(name string) (io.WriteCloser, error) {
 	return os.Create(name)
 }
+
+// readFile is used to provide an easier interface to use in tests to avoid reading real files.
+var readFile = ioutil.ReadFile
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -66,6 +67,82 @@ func TestFormatAndWrite(t *testing.T) {
 	})
 }
 
+func TestFormatAndWriteTo(t *testing.T) {
+	t.Run("should write formatted code to the given writer", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		err := FormatAndWriteTo([]byte("package   valley"), buf)
+		require.NoError(t, err)
+		assert.Equal(t, "package valley\n", buf.String())
+	})
+
+	t.Run("should error if the code cannot be formatted", func(t *testing.T) {
+		err := FormatAndWriteTo([]byte("this is invalid Go"), &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+
+	t.Run("should error if the writer cannot be written to", func(t *testing.T) {
+		err := FormatAndWriteTo([]byte("package valley"), &fakeIOWriteCloser{closed: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestCheck(t *testing.T) {
+	originalReadFile := readFile
+
+	t.Run("should return an empty diff if the destination file is up to date", func(t *testing.T) {
+		readFile = func(name string) ([]byte, error) {
+			return []byte("package valley\n"), nil
+		}
+
+		diff, err := Check([]byte("package   valley"), "test")
+		assert.NoError(t, err)
+		assert.Empty(t, diff)
+
+		readFile = originalReadFile
+	})
+
+	t.Run("should return a diff if the destination file is out of date", func(t *testing.T) {
+		readFile = func(name string) ([]byte, error) {
+			return []byte("package foo\n"), nil
+		}
+
+		diff, err := Check([]byte("package valley"), "test")
+		assert.NoError(t, err)
+		assert.Contains(t, diff, "-package foo\n+package valley\n")
+
+		readFile = originalReadFile
+	})
+
+	t.Run("should return a diff if the destination file doesn't exist", func(t *testing.T) {
+		readFile = func(name string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		}
+
+		diff, err := Check([]byte("package valley"), "test")
+		assert.NoError(t, err)
+		assert.Contains(t, diff, "+package valley\n")
+
+		readFile = originalReadFile
+	})
+
+	t.Run("should error if the destination file can't be read", func(t *testing.T) {
+		readFile = func(name string) ([]byte, error) {
+			return nil, errors.New("test")
+		}
+
+		_, err := Check([]byte("package valley"), "test")
+		assert.Error(t, err)
+
+		readFile = originalReadFile
+	})
+
+	t.Run("should error if the code cannot be formatted", func(t *testing.T) {
+		_, err := Check([]byte("this is invalid Go"), "test")
+		assert.Error(t, err)
+	})
+}
+
 func TestFindDestination(t *testing.T) {
 	t.Run("should add a suffix to the input source path", func(t *testing.T) {
 		expected := "/foo/bar/baz_validate.go"
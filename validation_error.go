@@ -0,0 +1,123 @@
+package valley
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError is an error that holds the ConstraintViolations found when validating a value, so
+// that validation failures can be returned like any other error, and detected using errors.As. It's
+// marshalled to JSON as an object containing the violations.
+type ValidationError struct {
+	Violations []ConstraintViolation `json:"violations"`
+}
+
+// NewValidationError returns a new *ValidationError holding the given violations, or nil if there
+// aren't any, so that the result can be returned as an error directly.
+func NewValidationError(violations []ConstraintViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &ValidationError{
+		Violations: violations,
+	}
+}
+
+// Error implements the error interface, describing each violation.
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, violation.Error())
+	}
+
+	return fmt.Sprintf("validation failed: %s", strings.Join(descriptions, "; "))
+}
+
+// As finds the first violation that matches the given target, so that a violation can be found
+// using errors.As, e.g. with a *ConstraintViolation as the target.
+func (e *ValidationError) As(target interface{}) bool {
+	for _, violation := range e.Violations {
+		if errors.As(violation, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Is returns true if the given target is a ConstraintViolation, and one of the violations matches
+// it, so that violations can be checked for using errors.Is. A violation matches if it has the same
+// path, code, and message as the target, ignoring any of them that are empty in the target, e.g.
+// ConstraintViolation{Code: CodeRequired} matches any violation of the Required constraint.
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(ConstraintViolation)
+	if !ok {
+		return false
+	}
+
+	for _, violation := range e.Violations {
+		if (t.Path == "" || t.Path == violation.Path) &&
+			(t.Code == "" || t.Code == violation.Code) &&
+			(t.Message == "" || t.Message == violation.Message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ByPath returns the violations grouped by their path.
+func (e *ValidationError) ByPath() map[string][]ConstraintViolation {
+	byPath := make(map[string][]ConstraintViolation)
+	for _, violation := range e.Violations {
+		byPath[violation.Path] = append(byPath[violation.Path], violation)
+	}
+
+	return byPath
+}
+
+// Fields returns the path of each violation, without duplicates, in the order they were found.
+func (e *ValidationError) Fields() []string {
+	var fields []string
+
+	seen := make(map[string]bool)
+	for _, violation := range e.Violations {
+		if !seen[violation.Path] {
+			fields = append(fields, violation.Path)
+			seen[violation.Path] = true
+		}
+	}
+
+	return fields
+}
+
+// Has returns true if there is at least one violation with the given path.
+func (e *ValidationError) Has(path string) bool {
+	for _, violation := range e.Violations {
+		if violation.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Translate returns a copy of this ValidationError, with the message of each violation rendered by
+// the given Translator in the language of the given locale (see TranslateAll).
+func (e *ValidationError) Translate(translator Translator, locale string) *ValidationError {
+	return &ValidationError{
+		Violations: TranslateAll(translator, locale, e.Violations),
+	}
+}
+
+// Error implements the error interface, so that a ConstraintViolation can be treated as an error on
+// it's own, e.g. when unwrapped from a ValidationError.
+func (v ConstraintViolation) Error() string {
+	if v.Path == "" {
+		return v.Message
+	}
+
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
@@ -0,0 +1,91 @@
+package valley
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidationError(t *testing.T) {
+	t.Run("should return nil if there are no violations", func(t *testing.T) {
+		assert.Nil(t, NewValidationError(nil))
+	})
+
+	t.Run("should return a *ValidationError holding the violations", func(t *testing.T) {
+		violations := []ConstraintViolation{{Path: ".name", Message: "a value is required"}}
+
+		err := NewValidationError(violations)
+		require.Error(t, err)
+
+		var validationError *ValidationError
+		require.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &validationError))
+		assert.Equal(t, violations, validationError.Violations)
+	})
+}
+
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{
+		Violations: []ConstraintViolation{
+			{Path: ".name", PathKind: "field", Message: "a value is required"},
+			{Path: ".age", PathKind: "field", Message: "minimum value not met"},
+			{Path: ".name", PathKind: "field", Message: "maximum length exceeded"},
+		},
+	}
+
+	t.Run("should describe each violation", func(t *testing.T) {
+		expected := "validation failed: .name: a value is required; .age: minimum value not met; .name: maximum length exceeded"
+		assert.EqualError(t, err, expected)
+	})
+
+	t.Run("should let violations be found using errors.As", func(t *testing.T) {
+		var violation ConstraintViolation
+		require.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &violation))
+		assert.Equal(t, err.Violations[0], violation)
+	})
+
+	t.Run("should let violations be checked for using errors.Is", func(t *testing.T) {
+		wrapped := fmt.Errorf("wrapped: %w", err)
+
+		assert.True(t, errors.Is(wrapped, ConstraintViolation{Path: ".age"}))
+		assert.True(t, errors.Is(wrapped, ConstraintViolation{Path: ".name", Message: "maximum length exceeded"}))
+		assert.False(t, errors.Is(wrapped, ConstraintViolation{Path: ".age", Message: "a value is required"}))
+		assert.False(t, errors.Is(wrapped, errors.New("a value is required")))
+	})
+
+	t.Run("should group violations by path", func(t *testing.T) {
+		byPath := err.ByPath()
+		assert.Len(t, byPath, 2)
+		assert.Len(t, byPath[".name"], 2)
+		assert.Len(t, byPath[".age"], 1)
+	})
+
+	t.Run("should return each path once, in order", func(t *testing.T) {
+		assert.Equal(t, []string{".name", ".age"}, err.Fields())
+	})
+
+	t.Run("should return whether there's a violation with a path", func(t *testing.T) {
+		assert.True(t, err.Has(".age"))
+		assert.False(t, err.Has(".email"))
+	})
+
+	t.Run("should marshal to JSON as an object containing the violations", func(t *testing.T) {
+		bs, jsonErr := json.Marshal(err)
+		require.NoError(t, jsonErr)
+		assert.JSONEq(t, `{"violations": [
+			{"path": ".name", "path_kind": "field", "message": "a value is required"},
+			{"path": ".age", "path_kind": "field", "message": "minimum value not met"},
+			{"path": ".name", "path_kind": "field", "message": "maximum length exceeded"}
+		]}`, string(bs))
+	})
+}
+
+func TestConstraintViolation_Error(t *testing.T) {
+	t.Run("should include the path, if there is one", func(t *testing.T) {
+		assert.EqualError(t, ConstraintViolation{Path: ".name", Message: "a value is required"}, ".name: a value is required")
+		assert.EqualError(t, ConstraintViolation{Message: "a value is required"}, "a value is required")
+	})
+}
@@ -0,0 +1,48 @@
+package valley
+
+import "reflect"
+
+// Validator is implemented by types that can validate themselves, i.e. every type that Valley has
+// generated a Validate method for.
+type Validator interface {
+	Validate(path *Path) []ConstraintViolation
+}
+
+// Validate validates the given value using it's Validate method, with a new Path, if it implements
+// Validator. Values that don't (including nil, and nil pointers) have nothing to validate, so nil is
+// returned for them. This allows values to be validated without knowing their concrete type, e.g.
+// request bodies decoded by some middleware.
+func Validate(v interface{}) []ConstraintViolation {
+	return ValidateAt(v, NewPath())
+}
+
+// ValidateAt validates the given value in the same way as Validate, but with the given Path, so that
+// the paths of violations are relative to it. Generated code uses this to validate values whose
+// concrete type isn't known, i.e. interface fields given the Valid constraint.
+func ValidateAt(v interface{}, path *Path) []ConstraintViolation {
+	validator, ok := v.(Validator)
+	if !ok || IsNilPointer(v) {
+		return nil
+	}
+
+	return validator.Validate(path)
+}
+
+// ValidateAll validates each of the given values using Validate, and returns all of the violations
+// found, in order.
+func ValidateAll(vs ...interface{}) []ConstraintViolation {
+	var violations []ConstraintViolation
+	for _, v := range vs {
+		violations = append(violations, Validate(v)...)
+	}
+
+	return violations
+}
+
+// IsNilPointer returns true if the given value is a nil pointer, which can't be validated (unless
+// it's validation method happens to handle that, which generated methods don't). Generated code uses
+// this to skip nil pointers held by interface fields.
+func IsNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
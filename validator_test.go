@@ -0,0 +1,79 @@
+package valley
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testValidator struct {
+	Name string
+}
+
+func (v testValidator) Validate(path *Path) []ConstraintViolation {
+	if v.Name != "" {
+		return nil
+	}
+
+	path.Write(".name")
+
+	return []ConstraintViolation{{Path: path.String(), PathKind: "field", Message: "a value is required"}}
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should validate values that implement Validator", func(t *testing.T) {
+		violations := Validate(testValidator{})
+		assert.Len(t, violations, 1)
+		assert.Equal(t, ".name", violations[0].Path)
+	})
+
+	t.Run("should validate pointers to values that implement Validator", func(t *testing.T) {
+		assert.Len(t, Validate(&testValidator{}), 1)
+		assert.Empty(t, Validate(&testValidator{Name: "valley"}))
+	})
+
+	t.Run("should return nil for nil pointers", func(t *testing.T) {
+		var v *testValidator
+		assert.Nil(t, Validate(v))
+	})
+
+	t.Run("should return nil for values that don't implement Validator", func(t *testing.T) {
+		assert.Nil(t, Validate("not a validator"))
+		assert.Nil(t, Validate(nil))
+	})
+}
+
+func TestValidateAt(t *testing.T) {
+	t.Run("should validate values relative to the given path", func(t *testing.T) {
+		path := NewPath()
+		path.Write(".body")
+
+		violations := ValidateAt(testValidator{}, path)
+		assert.Len(t, violations, 1)
+		assert.Equal(t, ".body.name", violations[0].Path)
+	})
+}
+
+func TestValidateAll(t *testing.T) {
+	t.Run("should return the violations for all of the given values, in order", func(t *testing.T) {
+		violations := ValidateAll(testValidator{}, "not a validator", testValidator{Name: "valley"}, &testValidator{})
+		assert.Len(t, violations, 2)
+	})
+
+	t.Run("should return nil if no values are given", func(t *testing.T) {
+		assert.Nil(t, ValidateAll())
+	})
+}
+
+func TestIsNilPointer(t *testing.T) {
+	t.Run("should return true for nil pointers", func(t *testing.T) {
+		var v *testValidator
+		assert.True(t, IsNilPointer(v))
+	})
+
+	t.Run("should return false for other values", func(t *testing.T) {
+		assert.False(t, IsNilPointer(&testValidator{}))
+		assert.False(t, IsNilPointer(testValidator{}))
+		assert.False(t, IsNilPointer(nil))
+	})
+}
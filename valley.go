@@ -3,7 +3,9 @@ package valley
 import (
 	"fmt"
 	"go/ast"
+	"go/parser"
 	"go/token"
+	"go/types"
 	"regexp"
 	"strings"
 	"time"
@@ -11,9 +13,13 @@ import (
 	"github.com/fatih/structtag"
 )
 
+// ImportPath is the import path that is used to import Valley types.
+const ImportPath = "github.com/seeruk/valley"
+
 // Built in regular expression patterns.
 var (
-	PatternUUID = regexp.MustCompile(`^[0-9A-f]{8}-[0-9A-f]{4}-[0-9A-f]{4}-[0-9A-f]{4}-[0-9A-f]{12}$`)
+	PatternEmail = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	PatternUUID  = regexp.MustCompile(`^[0-9A-f]{8}-[0-9A-f]{4}-[0-9A-f]{4}-[0-9A-f]{4}-[0-9A-f]{12}$`)
 )
 
 // Constraint is used to identify constraints to generate code for in a Go AST.
@@ -42,29 +48,75 @@ const (
 // PathKind enumerates possible path kinds that apply to constraint violations.
 type PathKind string
 
-// ConstraintViolation is the result of a validation failure.
+// Codes used by the built-in constraints to identify the kind of violation that occurred. Unlike
+// messages, these won't change, so they're safe to match against (e.g. to show different messages).
+const (
+	CodeAnyNRequired      = "any_n_required"
+	CodeDeepEquals        = "deep_equals"
+	CodeEquals            = "equals"
+	CodeExactlyNRequired  = "exactly_n_required"
+	CodeLength            = "length"
+	CodeMax               = "max"
+	CodeMaxLength         = "max_length"
+	CodeMin               = "min"
+	CodeMinLength         = "min_length"
+	CodeMutuallyExclusive = "mutually_exclusive"
+	CodeMutuallyInclusive = "mutually_inclusive"
+	CodeNil               = "nil"
+	CodeNotEquals         = "not_equals"
+	CodeNotNil            = "not_nil"
+	CodeOneOf             = "one_of"
+	CodePredicate         = "predicate"
+	CodeRegexp            = "regexp"
+	CodeRequired          = "required"
+	CodeTimeAfter         = "time_after"
+	CodeTimeBefore        = "time_before"
+)
+
+// ConstraintViolation is the result of a validation failure. Code is a stable, machine-readable
+// identifier for the kind of violation (see the Code constants for the built-in constraints).
+// Details hold more information about the violation, which for the built-in constraints is one of
+// the *Details types (e.g. MinDetails), and may be nil. CustomMessage is true if the message was
+// given using constraints.WithMessage, in which case Translators should keep it as it is.
 type ConstraintViolation struct {
-	Path     string                 `json:"path,omitempty"`
-	PathKind string                 `json:"path_kind"`
-	Message  string                 `json:"message"`
-	Details  map[string]interface{} `json:"details,omitempty"`
+	Path          string      `json:"path,omitempty"`
+	PathKind      string      `json:"path_kind"`
+	Code          string      `json:"code,omitempty"`
+	Message       string      `json:"message"`
+	Details       interface{} `json:"details,omitempty"`
+	CustomMessage bool        `json:"-"`
 }
 
 // Context is used to inform a ConstraintGenerator about it's environment, mainly to do with which
 // part of a type is being validated, and giving important identifiers to ConstraintGenerators.
+//
+// ResolvedType is the type of the value being validated (i.e. the type of VarName), as resolved by
+// the type checker. It will be nil if the type couldn't be resolved, in which case ConstraintGenerators
+// should fall back to inspecting the AST they're given.
+//
+// Optional is true if the value being validated may be empty, in which case ConstraintGenerators
+// that validate the contents of the value (e.g. it's length) should generate code that skips it if
+// it's empty. It's false if the value is required.
+//
+// Message and Code, if set, override the message and code of the violations the constraint
+// produces, as given using constraints.WithMessage and constraints.WithCode.
 type Context struct {
-	Source     Source
-	TypeName   string
-	Receiver   string
-	FieldName  string
-	FieldAlias string
-	TagName    string
-	VarName    string
-	Path       string
-	PathKind   PathKind
+	Source       Source
+	TypeName     string
+	Receiver     string
+	FieldName    string
+	FieldAlias   string
+	TagName      string
+	VarName      string
+	Path         string
+	PathKind     PathKind
+	ResolvedType types.Type
+	Optional     bool
 
 	Constraint      string
 	ConstraintNum   int
+	Message         string
+	Code            string
 	BeforeViolation string
 	AfterViolation  string
 }
@@ -85,15 +137,73 @@ type Package struct {
 	Name string
 }
 
-// Source represents the information Valley needs about a particular source file.
+// Source represents the information Valley needs about a particular source file. Methods and
+// structs are read from every file in the package that the source file belongs to, so that types
+// may be configured from any file in the package. Imports are only those of the source file itself.
+//
+// Functions are keyed by the type of their first parameter, as it's written in the source (e.g.
+// "User", or "pb.User" for types from other packages), and their Receiver is the name of that
+// parameter. Structs from other packages used this way are also included in Structs (and
+// StructNames).
 type Source struct {
-	FileName    string
-	FileSet     *token.FileSet
-	Package     string
-	Imports     []Import
-	Methods     Methods
-	Structs     Structs
-	StructNames []string
+	FileName     string
+	FileSet      *token.FileSet
+	Package      string
+	TypesPackage *types.Package
+	Imports      []Import
+	Methods      Methods
+	Functions    Methods
+	Structs      Structs
+	StructNames  []string
+}
+
+// TypeExpr returns a Go AST expression for the given resolved type, as it could be written in the
+// source file (i.e. using the source file's import aliases). This is useful when a type is known,
+// but there's no AST for it, e.g. the element type of a named slice type from another package. If
+// the type can't be represented as an expression, nil is returned.
+//
+// Types from packages that the source file doesn't import are referred to by their package's name,
+// and those packages are returned as imports, which generated code must add if it renders the
+// expression. The expression is parsed using it's own FileSet, so it has no useful positions.
+func (s Source) TypeExpr(typ types.Type) (ast.Expr, []Import) {
+	var imports []Import
+
+	typeString := types.TypeString(typ, func(pkg *types.Package) string {
+		if s.TypesPackage != nil && pkg.Path() == s.TypesPackage.Path() {
+			return ""
+		}
+
+		for _, imp := range s.Imports {
+			if imp.Path == pkg.Path() {
+				return imp.Alias
+			}
+		}
+
+		imp := Import{Path: pkg.Path(), Alias: pkg.Name()}
+		if !containsImport(imports, imp) {
+			imports = append(imports, imp)
+		}
+
+		return pkg.Name()
+	})
+
+	expr, err := parser.ParseExprFrom(token.NewFileSet(), "", typeString, 0)
+	if err != nil {
+		return nil, nil
+	}
+
+	return expr, imports
+}
+
+// containsImport returns true if the given import is in the given imports.
+func containsImport(imports []Import, imp Import) bool {
+	for _, i := range imports {
+		if i == imp {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Import represents information about a Go import that Valley uses to generate code.
@@ -111,35 +221,46 @@ type Variable struct {
 // Methods is a map from struct name to Method.
 type Methods map[string][]Method
 
-// Method represents the information we need about a method in some Go source code.
+// Method represents the information we need about a method in some Go source code. Pos is the
+// position of the method's name.
 type Method struct {
+	FileName string
 	Receiver string
 	Name     string
 	Params   *ast.FieldList
 	Results  *ast.FieldList
 	Body     *ast.BlockStmt
+	Pos      token.Pos
 }
 
 // Structs is a map from struct name to Struct.
 type Structs map[string]Struct
 
-// Struct represents the information we need about a struct in some Go source code.
+// Struct represents the information we need about a struct in some Go source code. TypeParams are
+// the names of the struct's type parameters, if it's generic.
 type Struct struct {
-	Name       string
-	Node       *ast.StructType
-	Fields     Fields
-	FieldNames []string
+	FileName     string
+	Name         string
+	Node         *ast.StructType
+	ResolvedType types.Type
+	TypeParams   []string
+	Fields       Fields
+	FieldNames   []string
 }
 
 // Fields is a map from struct field name to Value.
 type Fields map[string]Value
 
 // Value represents the information we need about a value (e.g. a struct, or a field on a struct) in
-// some Go source code.
+// some Go source code. ResolvedType is the type of the value as resolved by the type checker, which
+// is nil if it couldn't be resolved. Embedded is true if the value is an embedded struct field, in
+// which case Name is the name of it's type (as it is in Go).
 type Value struct {
-	Name string
-	Type ast.Expr
-	Tag  string
+	Name         string
+	Type         ast.Expr
+	ResolvedType types.Type
+	Tag          string
+	Embedded     bool
 }
 
 // GetFieldAliasFromTag ...
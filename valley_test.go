@@ -1,6 +1,8 @@
 package valley
 
 import (
+	"go/token"
+	"go/types"
 	"testing"
 	"time"
 
@@ -19,6 +21,46 @@ func TestContext_Clone(t *testing.T) {
 	})
 }
 
+func TestSource_TypeExpr(t *testing.T) {
+	local := types.NewPackage("example.com/local", "local")
+	imported := types.NewPackage("example.com/imported", "imported")
+	other := types.NewPackage("example.com/other", "other")
+
+	named := func(pkg *types.Package, name string) types.Type {
+		return types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), types.Typ[types.String], nil)
+	}
+
+	src := Source{
+		FileSet:      token.NewFileSet(),
+		TypesPackage: local,
+		Imports:      []Import{{Path: "example.com/imported", Alias: "imp"}},
+	}
+
+	t.Run("should use the source file's import aliases", func(t *testing.T) {
+		expr, imports := src.TypeExpr(types.NewSlice(named(imported, "T")))
+		assert.Equal(t, "[]imp.T", types.ExprString(expr))
+		assert.Empty(t, imports)
+	})
+
+	t.Run("should not qualify types from the source's own package", func(t *testing.T) {
+		expr, imports := src.TypeExpr(types.NewPointer(named(local, "T")))
+		assert.Equal(t, "*T", types.ExprString(expr))
+		assert.Empty(t, imports)
+	})
+
+	t.Run("should return imports for packages that aren't imported", func(t *testing.T) {
+		expr, imports := src.TypeExpr(types.NewMap(named(other, "K"), named(other, "V")))
+		assert.Equal(t, "map[other.K]other.V", types.ExprString(expr))
+		assert.Equal(t, []Import{{Path: "example.com/other", Alias: "other"}}, imports)
+	})
+
+	t.Run("should not add files to the source's FileSet", func(t *testing.T) {
+		base := src.FileSet.Base()
+		src.TypeExpr(named(imported, "T"))
+		assert.Equal(t, base, src.FileSet.Base())
+	})
+}
+
 func TestGetFieldAliasFromTag(t *testing.T) {
 	t.Run("should return the field name if the tag is empty", func(t *testing.T) {
 		alias, err := GetFieldAliasFromTag("testField", "valley", "")